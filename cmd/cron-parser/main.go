@@ -2,25 +2,124 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
+	"github.com/gondo/cron-parser/cron"
 	"github.com/gondo/cron-parser/internal/output"
-	"github.com/gondo/cron-parser/internal/parser"
+	"io"
 	"os"
+	"strconv"
+	"strings"
 )
 
+var seconds = flag.Bool("seconds", false, "expression starts with a seconds field")
+var dialect = flag.String("dialect", "", "expression dialect, one of: "+strings.Join(cron.Dialects(), ", "))
+var key = flag.String("key", "", "key hashed by Jenkins H, e.g. a job name")
+var ranges = flag.Bool("ranges", false, "show ranges of OpenBSD ~ instead of picking random values")
+var file = flag.String("file", "", "parse a crontab file instead of an expression, - reads standard input")
+var system = flag.Bool("system", false, "system crontab with a user name before the command, as in /etc/crontab")
+var describe = flag.Bool("describe", false, "describe the expression in English")
+var allErrors = flag.Bool("all-errors", false, "report every invalid field instead of the first one")
+var lang = flag.String("lang", "", "describe the expression in a language and accept its month and weekday names, one of: "+strings.Join(cron.Locales(), ", "))
+
+// Language of the description
+var locale = cron.English
+
 func main() {
-	input, err := processInput()
+	flag.Parse()
+
+	var options []cron.Option
+	if *seconds {
+		options = append(options, cron.WithSeconds())
+	}
+	if *dialect != "" {
+		options = append(options, cron.WithDialect(*dialect))
+	}
+	if *key != "" {
+		options = append(options, cron.WithKey(*key))
+	}
+	if *ranges {
+		options = append(options, cron.WithRandomRanges())
+	}
+	if *system {
+		options = append(options, cron.WithSystem())
+	}
+	if *allErrors {
+		options = append(options, cron.WithAllErrors())
+	}
+	if *lang != "" {
+		l, ok := cron.LookupLocale(*lang)
+		if !ok {
+			checkError(errors.New(fmt.Sprintf("unknown language `%s`", *lang)))
+		}
+		locale = l
+		*describe = true
+		options = append(options, cron.WithLocale(locale))
+	}
+
+	if *file != "" {
+		if len(flag.Args()) != 0 {
+			checkError(errors.New("invalid number of arguments"))
+		}
+		printCrontab(*file, options)
+		return
+	}
+
+	input, err := processInput(flag.Args())
 	checkError(err)
 
-	cron, command, err := parser.Parse(input, parser.Slots)
+	schedule, err := cron.Parse(input, options...)
 	checkError(err)
+	printSchedule(schedule)
+}
+
+// Entries of valid lines are printed before errors of the invalid ones.
+func printCrontab(path string, options []cron.Option) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		checkError(err)
+		defer f.Close()
+		r = f
+	}
+
+	entries, err := cron.ParseCrontab(r, options...)
+	for i := range entries {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Println(output.Row("line", strconv.Itoa(entries[i].Line)))
+		printSchedule(entries[i].Schedule)
+	}
+	checkError(err)
+}
 
-	fmt.Println(output.Table(cron))
-	fmt.Println(output.Row("command", command))
+func printSchedule(schedule *cron.Schedule) {
+	switch {
+	case schedule.Reboot:
+		fmt.Println(output.Row("trigger", "@reboot"))
+	case schedule.Every > 0:
+		fmt.Println(output.Row("every", schedule.Every.String()))
+	default:
+		fmt.Println(output.Table(schedule.Fields))
+	}
+	if schedule.User != "" {
+		fmt.Println(output.Row("user", schedule.User))
+	}
+	if schedule.Command != "" {
+		fmt.Println(output.Row("command", schedule.Command))
+	}
+	// Commands with `%` pass the rest to standard input
+	if command, stdin := schedule.SplitCommand(); command != schedule.Command {
+		fmt.Println(output.Row("run", command))
+		fmt.Println(output.Row("stdin", strings.ReplaceAll(stdin, "\n", `\n`)))
+	}
+	if *describe {
+		fmt.Println(output.Row("description", schedule.DescribeIn(locale)))
+	}
 }
 
-func processInput() (string, error) {
-	args := os.Args[1:]
+func processInput(args []string) (string, error) {
 	if len(args) != 1 {
 		return "", errors.New("invalid number of arguments")
 	}
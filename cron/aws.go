@@ -0,0 +1,152 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gondo/cron-parser/internal/parser"
+)
+
+// Standard five fields without a command, as converted to and from EventBridge
+var fieldsOnly = func() Dialect {
+	dialect := parser.Vixie
+	dialect.Name = "fields"
+	dialect.Command = false
+	dialect.Reboot = false
+	return dialect
+}()
+
+// FromAWS converts an AWS EventBridge expression to the standard five fields,
+// e.g. `cron(0 12 ? * MON-FRI *)` to `0 12 * * 1-5`. It fails for `rate(...)`, which counts
+// from the time the rule was created, and for a restricted year.
+func FromAWS(expression string) (string, error) {
+	schedule, err := Parse(expression, WithDialect(DialectAWS))
+	if err != nil {
+		return "", err
+	}
+	if schedule.Every > 0 {
+		return "", errors.New("rate expressions have no 5-field form")
+	}
+
+	year, _ := schedule.Field(Year)
+	if formatField(year, parser.AWSSlots[5], 0) != "*" {
+		return "", errors.New(fmt.Sprintf("restricted `%s` has no 5-field form", Year))
+	}
+
+	var sections []string
+	for i, slot := range parser.Slots {
+		sections = append(sections, formatField(schedule.Fields[i], slot, 0))
+	}
+	return strings.Join(sections, " "), nil
+}
+
+// ToAWS converts the standard five fields, or a macro, to an AWS EventBridge expression,
+// e.g. `0 12 * * 1-5` to `cron(0 12 ? * 2-6 *)` and `@every 2h` to `rate(2 hours)`.
+// It fails when both day of month and day of week are restricted, EventBridge requires `?` in one of them.
+// A day field starting with `*` is unrestricted as in Vixie cron, `1-31` is restricted but matches every day.
+func ToAWS(expression string) (string, error) {
+	parsed, err := parser.ParseDialect(expression, fieldsOnly)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Every > 0 {
+		return rate(parsed.Every)
+	}
+
+	schedule := &Schedule{}
+	schedule.setExpression(parsed)
+
+	var sections []string
+	stars := map[string]bool{}
+	for i, slot := range parser.Slots {
+		field := schedule.Fields[i]
+		stars[slot.Label] = star(field, span(slot.Min, slot.Max))
+		for _, special := range field.Specials {
+			if special.Kind == LastDay && special.Offset > 0 {
+				return "", errors.New(fmt.Sprintf("`%s` not supported by EventBridge", special))
+			}
+		}
+
+		shift := 0
+		if slot.Label == DayOfWeek {
+			// EventBridge numbers days of week 1-7 from Sunday
+			shift = 1
+		}
+		sections = append(sections, formatField(field, slot, shift))
+	}
+
+	dom, dow := 2, 4
+	bothRestricted := errors.New(fmt.Sprintf("`%s` and `%s` both restricted, EventBridge requires `?` in one of them", DayOfMonth, DayOfWeek))
+	switch {
+	// Both days have to match as in Vixie cron, so one of them has to match every day
+	case stars[DayOfMonth] || stars[DayOfWeek]:
+		switch {
+		case sections[dow] == "*":
+			sections[dow] = "?"
+		case sections[dom] == "*":
+			sections[dom] = "?"
+		default:
+			return "", bothRestricted
+		}
+	// Either day matches, one matching every day makes every day match
+	case sections[dom] == "*" || sections[dow] == "*":
+		sections[dom], sections[dow] = "*", "?"
+	default:
+		return "", bothRestricted
+	}
+	return fmt.Sprintf("cron(%s *)", strings.Join(sections, " ")), nil
+}
+
+func rate(every time.Duration) (string, error) {
+	for _, unit := range []struct {
+		name     string
+		duration time.Duration
+	}{{"day", 24 * time.Hour}, {"hour", time.Hour}, {"minute", time.Minute}} {
+		if every%unit.duration != 0 {
+			continue
+		}
+		value := int(every / unit.duration)
+		if value != 1 {
+			unit.name += "s"
+		}
+		return fmt.Sprintf("rate(%d %s)", value, unit.name), nil
+	}
+	return "", errors.New(fmt.Sprintf("interval `%s` not in whole minutes", every))
+}
+
+// formatField writes a field back as an expression section, `*` when it covers the whole slot.
+// Days of week are moved by shift for dialects numbering them differently.
+func formatField(field Field, slot Slot, shift int) string {
+	if len(field.Specials) == 0 && len(field.Items) == slot.Max-slot.Min+1 {
+		return "*"
+	}
+
+	var parts []string
+	items := field.Items
+	for i := 0; i < len(items); {
+		// Runs of three and more consecutive values become a range
+		j := i
+		for j+1 < len(items) && items[j+1] == items[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			parts = append(parts, fmt.Sprintf("%d-%d", items[i]+shift, items[j]+shift))
+		} else {
+			for k := i; k <= j; k++ {
+				parts = append(parts, strconv.Itoa(items[k]+shift))
+			}
+		}
+		i = j + 1
+	}
+
+	for _, special := range field.Specials {
+		if special.Kind == LastWeekday || special.Kind == NthWeekday {
+			special.Value += shift
+		}
+		parts = append(parts, special.String())
+	}
+	return strings.Join(parts, ",")
+}
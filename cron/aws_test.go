@@ -0,0 +1,169 @@
+package cron
+
+import "testing"
+
+func TestFromAWS(t *testing.T) {
+	testCases := map[string]struct {
+		input       string
+		expected    string
+		expectedErr string
+	}{
+		"Weekdays": {
+			input:    `cron(0 12 ? * MON-FRI *)`,
+			expected: `0 12 * * 1-5`,
+		},
+		"Day of month": {
+			input:    `cron(0/15 8,17 1,15 JAN-MAR ? *)`,
+			expected: `0,15,30,45 8,17 1,15 1-3 *`,
+		},
+		"Special days": {
+			input:    `cron(0 0 ? * 6L,2#1 *)`,
+			expected: `0 0 * * 5L,1#1`,
+		},
+		"Unwrapped": {
+			input:    `0 10 LW * ? *`,
+			expected: `0 10 LW * *`,
+		},
+
+		// Errors
+
+		"Rate": {
+			input:       `rate(5 minutes)`,
+			expectedErr: "rate expressions have no 5-field form",
+		},
+		"Days before last": {
+			input:       `cron(0 12 L-3 * ? *)`,
+			expectedErr: "invalid item `L-3` in `day of month`",
+		},
+		"Year": {
+			input:       `cron(0 12 ? * MON-FRI 2022)`,
+			expectedErr: "restricted `year` has no 5-field form",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := FromAWS(testCase.input)
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if got != testCase.expected {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestToAWS(t *testing.T) {
+	testCases := map[string]struct {
+		input       string
+		expected    string
+		expectedErr string
+	}{
+		"Weekdays": {
+			input:    `0 12 * * 1-5`,
+			expected: `cron(0 12 ? * 2-6 *)`,
+		},
+		"Day of month": {
+			input:    `*/20 0 1,15 * *`,
+			expected: `cron(0,20,40 0 1,15 * ? *)`,
+		},
+		"Every day": {
+			input:    `30 6 * * *`,
+			expected: `cron(30 6 * * ? *)`,
+		},
+		"Day of month range": {
+			input:    `0 12 1-31 * 1`,
+			expected: `cron(0 12 * * ? *)`,
+		},
+		"Day of week range": {
+			input:    `0 12 1,15 * 0-6`,
+			expected: `cron(0 12 * * ? *)`,
+		},
+		"Special days": {
+			input:    `0 0 * * 5L`,
+			expected: `cron(0 0 ? * 6L *)`,
+		},
+		"Macro": {
+			input:    `@monthly`,
+			expected: `cron(0 0 1 * ? *)`,
+		},
+		"Every hours": {
+			input:    `@every 2h`,
+			expected: `rate(2 hours)`,
+		},
+		"Every day interval": {
+			input:    `@every 24h`,
+			expected: `rate(1 day)`,
+		},
+
+		// Errors
+
+		"Both days": {
+			input:       `0 0 1 * 1`,
+			expectedErr: "`day of month` and `day of week` both restricted, EventBridge requires `?` in one of them",
+		},
+		"Both days with a step": {
+			input:       `0 0 1,15 * */2`,
+			expectedErr: "`day of month` and `day of week` both restricted, EventBridge requires `?` in one of them",
+		},
+		"Days before last": {
+			input:       `0 0 L-3 * *`,
+			expectedErr: "`L-3` not supported by EventBridge",
+		},
+		"Seconds interval": {
+			input:       `@every 90s`,
+			expectedErr: "interval `1m30s` not in whole minutes",
+		},
+		"Reboot": {
+			input:       `@reboot`,
+			expectedErr: "macro `@reboot` not supported",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ToAWS(testCase.input)
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if got != testCase.expected {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expected, got)
+			}
+
+			if _, err := Parse(got, WithDialect(DialectAWS)); err != nil {
+				t.Errorf("expected a valid EventBridge expression, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gondo/cron-parser/internal/parser"
+)
+
+// Field labels
+const (
+	Second     = parser.LabelSecond
+	Minute     = parser.LabelMinute
+	Hour       = parser.LabelHour
+	DayOfMonth = parser.LabelDayOfMonth
+	Month      = parser.LabelMonth
+	DayOfWeek  = parser.LabelDayOfWeek
+	Year       = parser.LabelYear
+)
+
+// Field holds the expanded values of one part of an expression, e.g. minute.
+// Days that depend on the month, such as `L`, are kept in Specials.
+type Field struct {
+	Label    string
+	Items    []int
+	Specials []Special
+	// OpenBSD `~` units the Items were picked from
+	Random []Random
+	// Parts of the field as written, e.g. one `1-5` rather than five items
+	Units []Unit
+}
+
+// Special is a day resolved per month, e.g. the last day of month.
+type Special = parser.Special
+
+// Unit is one comma separated part of a field as written, e.g. `*/15`.
+type Unit = parser.Unit
+
+// Random is an OpenBSD `a~b` unit, a value picked from the range at parse time.
+type Random = parser.Random
+
+// SpecialKind tells what a Special day means.
+type SpecialKind = parser.SpecialKind
+
+// Special day kinds
+const (
+	// `L` or `L-n` in day of month, the last day of month or n days before it
+	LastDay = parser.LastDay
+	// `nL` in day of week, the last given weekday of month
+	LastWeekday = parser.LastWeekday
+	// `nW` in day of month, the Monday to Friday closest to day n within the month
+	NearestWorkday = parser.NearestWorkday
+	// `LW` in day of month, the last Monday to Friday of month
+	LastWorkday = parser.LastWorkday
+	// `d#n` in day of week, the n-th given weekday of month
+	NthWeekday = parser.NthWeekday
+)
+
+// Schedule is a parsed cron expression together with its command.
+type Schedule struct {
+	Fields []Field
+	// Command as written, see SplitCommand for what runs
+	Command string
+	// User the command runs as, only in system crontabs, see WithSystem
+	User string
+	// Reboot schedules run once at startup, they have no fields and never fire on time
+	Reboot bool
+	// Interval of `@every` schedules, they have no fields
+	Every time.Duration
+	// How day of month and day of week combine, set by the dialect
+	DayRule DayRule
+	// Location in which fire times are computed, nil means the location of the time passed in
+	Location *time.Location
+	DST      DSTPolicy
+	// Delay of every fire time, none by default
+	Jitter Jitter
+	// Some fields failed to parse with WithAllErrors, the schedule lacks them and never fires
+	Incomplete bool
+}
+
+// SplitCommand returns what the shell runs and what it gets on standard input, as crontab(5) describes:
+// the command ends at the first `%`, the rest is its input with every further `%` as a newline.
+// An escaped `\%` is a plain `%`, e.g. `mail -s "50\% done" ops%Backup%done` runs `mail -s "50% done" ops`
+// with input "Backup\ndone".
+func (s *Schedule) SplitCommand() (command string, stdin string) {
+	return parser.SplitCommand(s.Command)
+}
+
+// Field returns the field with the given label, e.g. cron.Hour.
+func (s *Schedule) Field(label string) (Field, bool) {
+	for i := range s.Fields {
+		if s.Fields[i].Label == label {
+			return s.Fields[i], true
+		}
+	}
+	return Field{}, false
+}
+
+// Parse parses a cron expression followed by a command, by default the standard five fields
+// of the Vixie dialect, e.g. `*/15 0 1,15 * 1-5 /usr/bin/find`. See WithDialect for others.
+// Nicknames such as `@daily` expand to their fields,
+// `@reboot` gives a schedule with Reboot set and `@every 1h30m` one with Every set.
+// The expression may start with a time zone as Vixie cron allows,
+// e.g. `CRON_TZ=Europe/Prague 0 9 * * * /usr/bin/find`. Invalid expressions give a *ParseError,
+// or ParseErrors along with a schedule of the valid fields with WithAllErrors.
+func Parse(expression string, options ...Option) (*Schedule, error) {
+	settings := newSettings(options)
+	if settings.err != nil {
+		return nil, settings.err
+	}
+	schedule := &Schedule{Location: settings.location, DST: settings.dst, Jitter: settings.jitter}
+
+	trimmed := strings.TrimSpace(expression)
+	leading := strings.Index(expression, trimmed)
+	zone, rest := splitTimeZone(expression)
+	var errs ParseErrors
+	if zone != "" {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			offset := leading + strings.Index(trimmed, "=") + 1
+			zoneErr := &ParseError{Code: CodeTimeZone, Field: -1, Token: zone, Offset: offset, Message: fmt.Sprintf("invalid time zone `%s`", zone)}
+			if !settings.allErrors {
+				return nil, zoneErr
+			}
+			errs = append(errs, zoneErr)
+		}
+		schedule.Location = loc
+	}
+
+	dialect := settings.named(settings.dialect)
+	if settings.system {
+		if !dialect.Command {
+			return nil, errors.New(fmt.Sprintf("dialect `%s` has no command to run as a user", dialect.Name))
+		}
+		dialect.User = true
+	}
+
+	parse := parser.ParseWith
+	if settings.allErrors {
+		parse = parser.ParseAll
+	}
+	parsed, err := parse(rest, dialect, settings.sources)
+	// Offsets point into the expression as written, time zone included
+	for _, e := range asParseErrors(err) {
+		e.Offset += leading + len(trimmed) - len(rest)
+	}
+	if err != nil && !settings.allErrors {
+		return nil, err
+	}
+
+	schedule.setExpression(parsed)
+	errs = append(errs, asParseErrors(err)...)
+	if len(errs) > 0 {
+		schedule.Incomplete = true
+		return schedule, errs
+	}
+	return schedule, nil
+}
+
+func (s *Schedule) setExpression(expression parser.Expression) {
+	s.Command = expression.Command
+	s.User = expression.User
+	s.Reboot = expression.Reboot
+	s.Every = expression.Every
+	s.DayRule = expression.DayRule
+	for i := range expression.Results {
+		res := expression.Results[i]
+		field := Field{Label: res.Label, Items: res.Items, Specials: res.Specials, Random: res.Random}
+		if i < len(expression.Units) {
+			field.Units = expression.Units[i]
+		}
+		s.Fields = append(s.Fields, field)
+	}
+}
+
+var timeZonePrefixes = []string{"CRON_TZ=", "TZ="}
+
+// splitTimeZone separates a leading time zone assignment from the rest of the expression.
+func splitTimeZone(expression string) (string, string) {
+	expression = strings.TrimSpace(expression)
+	for _, prefix := range timeZonePrefixes {
+		if !strings.HasPrefix(expression, prefix) {
+			continue
+		}
+		rest := expression[len(prefix):]
+		end := strings.IndexAny(rest, " \t")
+		if end < 0 {
+			return rest, ""
+		}
+		return rest[:end], rest[end:]
+	}
+	return "", expression
+}
@@ -0,0 +1,513 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		input            string
+		expectedSchedule *Schedule
+		expectedErr      string
+	}{
+		"Assigment": {
+			input: `*/15 0 1,15 * 1-5 /usr/bin/find`,
+			expectedSchedule: &Schedule{
+				Fields: []Field{
+					{
+						Label: "minute",
+						Items: []int{0, 15, 30, 45},
+						Units: []Unit{{Any: true, Start: 0, End: 59, Step: 15}},
+					},
+					{
+						Label: "hour",
+						Items: []int{0},
+						Units: []Unit{{Start: 0, End: 0}},
+					},
+					{
+						Label: "day of month",
+						Items: []int{1, 15},
+						Units: []Unit{{Start: 1, End: 1}, {Start: 15, End: 15}},
+					},
+					{
+						Label: "month",
+						Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+						Units: []Unit{{Any: true, Start: 1, End: 12}},
+					},
+					{
+						Label: "day of week",
+						Items: []int{1, 2, 3, 4, 5},
+						Units: []Unit{{Start: 1, End: 5}},
+					},
+				},
+				Command: `/usr/bin/find`,
+			},
+			expectedErr: "",
+		},
+		"Reboot": {
+			input: `@reboot /usr/bin/find`,
+			expectedSchedule: &Schedule{
+				Command: `/usr/bin/find`,
+				Reboot:  true,
+			},
+			expectedErr: "",
+		},
+
+		// Errors
+
+		"Short input": {
+			input:       `0 0 /usr/bin/find`,
+			expectedErr: "invalid number of sections",
+		},
+		"Out of range": {
+			input:       `* * 0 * * /usr/bin/find`,
+			expectedErr: "item `0` out of range in `day of month`",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.input)
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+					return
+				}
+
+				// Ignore other results
+				return
+			}
+
+			if testCase.expectedErr == "" && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if !reflect.DeepEqual(schedule, testCase.expectedSchedule) {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expectedSchedule, schedule)
+			}
+		})
+	}
+}
+
+func TestParseSeconds(t *testing.T) {
+	schedule, err := Parse(`*/20 0 0 1 1 * /usr/bin/find`, WithSeconds())
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+		return
+	}
+
+	expected := []Field{
+		{Label: "second", Items: []int{0, 20, 40}, Units: []Unit{{Any: true, Start: 0, End: 59, Step: 20}}},
+		{Label: "minute", Items: []int{0}, Units: []Unit{{Start: 0, End: 0}}},
+		{Label: "hour", Items: []int{0}, Units: []Unit{{Start: 0, End: 0}}},
+		{Label: "day of month", Items: []int{1}, Units: []Unit{{Start: 1, End: 1}}},
+		{Label: "month", Items: []int{1}, Units: []Unit{{Start: 1, End: 1}}},
+		{Label: "day of week", Items: []int{0, 1, 2, 3, 4, 5, 6}, Units: []Unit{{Any: true, Start: 0, End: 6}}},
+	}
+	if !reflect.DeepEqual(schedule.Fields, expected) {
+		t.Errorf("expected: %v\nbut got: %v", expected, schedule.Fields)
+	}
+
+	if _, err := Parse(`0 0 1 1 * /usr/bin/find`, WithSeconds()); err == nil {
+		t.Errorf("expected error for five fields, got nothing")
+	}
+}
+
+func TestParseTimeZone(t *testing.T) {
+	testCases := map[string]struct {
+		input            string
+		expectedLocation string
+		expectedCommand  string
+		expectedErr      string
+	}{
+		"No zone": {
+			input:            `0 9 * * * /usr/bin/find`,
+			expectedLocation: "",
+			expectedCommand:  `/usr/bin/find`,
+		},
+		"Cron zone": {
+			input:            `CRON_TZ=Europe/Prague 0 9 * * * /usr/bin/find`,
+			expectedLocation: "Europe/Prague",
+			expectedCommand:  `/usr/bin/find`,
+		},
+		"Zone and tab": {
+			input:            "TZ=UTC\t0 9 * * * /usr/bin/find",
+			expectedLocation: "UTC",
+			expectedCommand:  `/usr/bin/find`,
+		},
+		"Zone": {
+			input:            `TZ=UTC 0 9 * * * /usr/bin/find`,
+			expectedLocation: "UTC",
+			expectedCommand:  `/usr/bin/find`,
+		},
+
+		// Errors
+
+		"Unknown zone": {
+			input:       `CRON_TZ=Mars/Olympus 0 9 * * * /usr/bin/find`,
+			expectedErr: "invalid time zone `Mars/Olympus`",
+		},
+		"Zone only": {
+			input:       `CRON_TZ=UTC`,
+			expectedErr: "invalid number of sections",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.input)
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			location := ""
+			if schedule.Location != nil {
+				location = schedule.Location.String()
+			}
+			if location != testCase.expectedLocation {
+				t.Errorf("expected location: %v\nbut got: %v", testCase.expectedLocation, location)
+			}
+
+			if schedule.Command != testCase.expectedCommand {
+				t.Errorf("expected command: %v\nbut got: %v", testCase.expectedCommand, schedule.Command)
+			}
+		})
+	}
+}
+
+func TestParseRandom(t *testing.T) {
+	expression := `0~59 3 * * * /usr/bin/find`
+	first, err := Parse(expression, WithDialect(DialectOpenBSD), WithRand(rand.New(rand.NewSource(7))))
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+		return
+	}
+	second, _ := Parse(expression, WithDialect(DialectOpenBSD), WithRand(rand.New(rand.NewSource(7))))
+	if !reflect.DeepEqual(first.Fields, second.Fields) {
+		t.Errorf("expected the same source to pick the same values: %v\nbut got: %v", first.Fields, second.Fields)
+	}
+
+	minute, _ := first.Field(Minute)
+	if len(minute.Items) != 1 || len(minute.Random) != 1 || minute.Items[0] != minute.Random[0].Value {
+		t.Errorf("expected one picked minute, got: %v", minute)
+	}
+
+	ranges, err := Parse(`0~2 3 * * * /usr/bin/find`, WithDialect(DialectOpenBSD), WithRandomRanges())
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+		return
+	}
+	expected := Field{Label: Minute, Items: []int{0, 1, 2}, Random: []Random{{Min: 0, Max: 2}}, Units: []Unit{{Start: 0, End: 2}}}
+	if minute, _ := ranges.Field(Minute); !reflect.DeepEqual(minute, expected) {
+		t.Errorf("expected: %v\nbut got: %v", expected, minute)
+	}
+}
+
+func TestParseSystem(t *testing.T) {
+	schedule, err := Parse(`0 4 * * * root /usr/bin/find`, WithSystem())
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+		return
+	}
+	if schedule.User != "root" || schedule.Command != "/usr/bin/find" {
+		t.Errorf("expected user root and command /usr/bin/find\nbut got user %v and command %v", schedule.User, schedule.Command)
+	}
+
+	if _, err := Parse(`0 4 * * * /usr/bin/find -x`, WithSystem()); err == nil || err.Error() != "invalid user `/usr/bin/find`" {
+		t.Errorf("expected error: invalid user `/usr/bin/find`\nbut got: %v", err)
+	}
+
+	expectedErr := "dialect `quartz` has no command to run as a user"
+	if _, err := Parse(`0 0 4 * * ?`, WithQuartz(), WithSystem()); err == nil || err.Error() != expectedErr {
+		t.Errorf("expected error: %v\nbut got: %v", expectedErr, err)
+	}
+}
+
+func TestParseNames(t *testing.T) {
+	testCases := map[string]struct {
+		expression       string
+		options          []Option
+		expectedMonth    []int
+		expectedWeekdays []int
+		expectedErr      string
+	}{
+		"Full English names": {
+			expression:       `0 9 * January,march Monday-Friday /usr/bin/find`,
+			expectedMonth:    []int{1, 3},
+			expectedWeekdays: []int{1, 2, 3, 4, 5},
+		},
+		"Added names": {
+			expression:       `0 9 * * lun-mar /usr/bin/find`,
+			options:          []Option{WithNames(DayOfWeek, Names{"lun": 1, "mar": 2})},
+			expectedMonth:    []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+			expectedWeekdays: []int{1, 2},
+		},
+		"Locale names": {
+			expression:       `0 9 * März Sonntag,Montag /usr/bin/find`,
+			options:          []Option{WithLocale(german)},
+			expectedMonth:    []int{3},
+			expectedWeekdays: []int{0, 1},
+		},
+		"Locale names in Quartz": {
+			expression:       `0 0 9 ? * Samstag`,
+			options:          []Option{WithLocale(german), WithQuartz()},
+			expectedMonth:    []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+			expectedWeekdays: []int{6},
+		},
+
+		// Errors
+
+		"Locale names without locale": {
+			expression:  `0 9 * * Montag /usr/bin/find`,
+			expectedErr: "unrecognized token `Montag` in `day of week`",
+		},
+		"POSIX without names": {
+			expression:  `0 9 * * Montag /usr/bin/find`,
+			options:     []Option{WithLocale(german), WithDialect(DialectPOSIX)},
+			expectedErr: "`Montag` does not match expected pattern `^[\\d|\\*|\\-|,]+$` in `day of week`",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression, testCase.options...)
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			month, _ := schedule.Field(Month)
+			if !reflect.DeepEqual(month.Items, testCase.expectedMonth) {
+				t.Errorf("expected months: %v\nbut got: %v", testCase.expectedMonth, month.Items)
+			}
+			weekdays, _ := schedule.Field(DayOfWeek)
+			if !reflect.DeepEqual(weekdays.Items, testCase.expectedWeekdays) {
+				t.Errorf("expected weekdays: %v\nbut got: %v", testCase.expectedWeekdays, weekdays.Items)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	testCases := map[string]struct {
+		expression     string
+		expectedCode   ErrorCode
+		expectedToken  string
+		expectedOffset int
+	}{
+		"Field": {
+			expression:     `0 25 * * * /usr/bin/find`,
+			expectedCode:   CodeOutOfRange,
+			expectedToken:  "25",
+			expectedOffset: 2,
+		},
+		"After time zone": {
+			expression:     ` CRON_TZ=Europe/Prague  0 25 * * * /usr/bin/find`,
+			expectedCode:   CodeOutOfRange,
+			expectedToken:  "25",
+			expectedOffset: 26,
+		},
+		"Time zone": {
+			expression:     `CRON_TZ=Nowhere/City 0 9 * * * /usr/bin/find`,
+			expectedCode:   CodeTimeZone,
+			expectedToken:  "Nowhere/City",
+			expectedOffset: 8,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(testCase.expression)
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Errorf("expected a parse error, got: %v", err)
+				return
+			}
+			if parseErr.Code != testCase.expectedCode || parseErr.Token != testCase.expectedToken || parseErr.Offset != testCase.expectedOffset {
+				t.Errorf("expected code %v, token `%s` at %d\nbut got code %v, token `%s` at %d",
+					testCase.expectedCode, testCase.expectedToken, testCase.expectedOffset, parseErr.Code, parseErr.Token, parseErr.Offset)
+			}
+		})
+	}
+}
+
+func TestParseAllErrors(t *testing.T) {
+	schedule, err := Parse(`CRON_TZ=Nowhere/City 0 25 * * mon-frx /usr/bin/find`, WithAllErrors())
+
+	var errs ParseErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("expected parse errors, got: %v", err)
+		return
+	}
+	expected := []struct {
+		code   ErrorCode
+		token  string
+		offset int
+	}{
+		{CodeTimeZone, "Nowhere/City", 8},
+		{CodeOutOfRange, "25", 23},
+		{CodeToken, "frx", 34},
+	}
+	if len(errs) != len(expected) {
+		t.Errorf("expected %d errors, got: %v", len(expected), err)
+		return
+	}
+	for i := range expected {
+		if errs[i].Code != expected[i].code || errs[i].Token != expected[i].token || errs[i].Offset != expected[i].offset {
+			t.Errorf("expected code %v, token `%s` at %d\nbut got code %v, token `%s` at %d",
+				expected[i].code, expected[i].token, expected[i].offset, errs[i].Code, errs[i].Token, errs[i].Offset)
+		}
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Code != CodeTimeZone {
+		t.Errorf("expected the first parse error to be found, got: %v", parseErr)
+	}
+
+	if schedule == nil || schedule.Command != "/usr/bin/find" {
+		t.Errorf("expected a schedule of the valid fields, got: %v", schedule)
+		return
+	}
+	if _, ok := schedule.Field(Hour); ok {
+		t.Errorf("expected no invalid hour field")
+	}
+	if minute, ok := schedule.Field(Minute); !ok || !reflect.DeepEqual(minute.Items, []int{0}) {
+		t.Errorf("expected minute 0, got: %v", minute)
+	}
+	if !schedule.Incomplete {
+		t.Errorf("expected an incomplete schedule")
+	}
+
+	if _, err := Parse(`0 25 * * mon-frx /usr/bin/find`); err == nil || err.Error() != "item `25` out of range in `hour`" {
+		t.Errorf("expected only the first error without WithAllErrors, got: %v", err)
+	}
+}
+
+func TestIncompleteSchedule(t *testing.T) {
+	schedule, err := Parse(`a 0 * * * /usr/bin/find`, WithAllErrors())
+	if err == nil || schedule == nil || !schedule.Incomplete {
+		t.Errorf("expected an incomplete schedule with an error, got: %v, %v", schedule, err)
+		return
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if next := schedule.Next(now); !next.IsZero() {
+		t.Errorf("expected no next time, got: %v", next)
+	}
+	if prev := schedule.Prev(now); !prev.IsZero() {
+		t.Errorf("expected no previous time, got: %v", prev)
+	}
+	it := schedule.Upcoming(context.Background(), now, 3)
+	if it.Next() {
+		t.Errorf("expected no upcoming time, got: %v", it.Time())
+	}
+
+	schedule, err = Parse(`0 0 * * * /usr/bin/find`, WithAllErrors())
+	if err != nil || schedule.Incomplete || schedule.Next(now).IsZero() {
+		t.Errorf("expected a complete schedule firing, got: %v, %v", schedule, err)
+	}
+}
+
+func TestLookupDialectCopy(t *testing.T) {
+	dialect, _ := LookupDialect(DialectVixie)
+	dialect.Name = "morning"
+	dialect.Slots[1].Max = 11
+	dialect.Slots[3].Names["foo"] = 3
+	dialect.Macros["@noon"] = "0 12 * * *"
+	if err := RegisterDialect(dialect); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+		return
+	}
+	// Changes after registering do not reach the registered dialect either
+	dialect.Slots[1].Max = 5
+
+	if _, err := Parse(`0 15 * foo * cmd`); err == nil || err.Error() != "unrecognized token `foo` in `month`" {
+		t.Errorf("expected the built-in dialect unchanged, got: %v", err)
+	}
+	if _, err := Parse(`0 15 * * * cmd`); err != nil {
+		t.Errorf("expected the built-in dialect unchanged, got: %v", err)
+	}
+	if _, err := Parse(`@noon cmd`); err == nil {
+		t.Errorf("expected the built-in macros unchanged")
+	}
+
+	if _, err := Parse(`0 9 * * * cmd`, WithDialect("morning")); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	expectedErr := "item `15` out of range in `hour`"
+	if _, err := Parse(`0 15 * * * cmd`, WithDialect("morning")); err == nil || err.Error() != expectedErr {
+		t.Errorf("expected error: %v\nbut got: %v", expectedErr, err)
+	}
+}
+
+func TestNamesCopy(t *testing.T) {
+	MonthNames()["foo"] = 3
+	DayOfWeekNames()["bar"] = 1
+
+	if _, err := Parse(`0 15 * foo * cmd`); err == nil {
+		t.Errorf("expected month names unchanged")
+	}
+	if _, err := Parse(`0 15 * * bar cmd`); err == nil {
+		t.Errorf("expected weekday names unchanged")
+	}
+	if MonthNames()["march"] != 3 || DayOfWeekNames()["sun"] != 0 {
+		t.Errorf("expected English names, got: %v and %v", MonthNames(), DayOfWeekNames())
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	schedule, err := Parse(`0 9 * * * mail -s "50\% done"  ops%Backup%done`)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+		return
+	}
+
+	if schedule.Command != `mail -s "50\% done"  ops%Backup%done` {
+		t.Errorf("expected the command as written, got: %v", schedule.Command)
+	}
+
+	command, stdin := schedule.SplitCommand()
+	if command != `mail -s "50% done"  ops` || stdin != "Backup\ndone" {
+		t.Errorf("expected command `mail -s \"50%% done\"  ops` with input %q\nbut got: %v with input %q", "Backup\ndone", command, stdin)
+	}
+}
@@ -0,0 +1,137 @@
+package cron
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Entry is a schedule of a crontab file.
+type Entry struct {
+	// Line number in the file, starting at 1
+	Line     int
+	Schedule *Schedule
+	// Environment assignments of the file in effect for the entry
+	Env map[string]string
+}
+
+// LineError is a crontab line that could not be parsed.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// CrontabError lists every line of a crontab that could not be parsed.
+type CrontabError []*LineError
+
+func (e CrontabError) Error() string {
+	var lines []string
+	for i := range e {
+		lines = append(lines, e[i].Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap lets errors.As find each of the line errors and the errors they hold.
+func (e CrontabError) Unwrap() []error {
+	var errs []error
+	for i := range e {
+		errs = append(errs, e[i])
+	}
+	return errs
+}
+
+// `NAME = value`, spaces around `=` allowed
+var assignment = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+
+// ParseCrontab parses a crontab file as described in crontab(5). Blank lines and lines starting with `#`
+// are skipped, `NAME=value` lines set environment variables for the entries below them
+// and every other line is an entry parsed with the given options.
+// A `CRON_TZ` or `TZ` variable sets the location of the entries below it.
+//
+// Entries of valid lines are returned even when some lines are invalid, the error is then a CrontabError.
+func ParseCrontab(r io.Reader, options ...Option) ([]Entry, error) {
+	var entries []Entry
+	var lineErrors CrontabError
+	env := map[string]string{}
+	var location *time.Location
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if name, value, ok := parseAssignment(line); ok {
+			if name == "CRON_TZ" || name == "TZ" {
+				loc, err := time.LoadLocation(value)
+				if err != nil {
+					err := &ParseError{Code: CodeTimeZone, Field: -1, Token: value, Offset: strings.LastIndex(scanner.Text(), value), Message: fmt.Sprintf("invalid time zone `%s`", value)}
+					lineErrors = append(lineErrors, &LineError{Line: n, Err: err})
+					continue
+				}
+				location = loc
+			}
+			env[name] = value
+			continue
+		}
+
+		lineOptions := options
+		if location != nil {
+			lineOptions = append(append([]Option{}, options...), WithLocation(location))
+		}
+		// Offsets of errors point into the line as written
+		schedule, err := Parse(scanner.Text(), lineOptions...)
+		if err != nil {
+			lineErrors = append(lineErrors, &LineError{Line: n, Err: err})
+			continue
+		}
+		entries = append(entries, Entry{Line: n, Schedule: schedule, Env: copyEnv(env)})
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, err
+	}
+
+	if len(lineErrors) > 0 {
+		return entries, lineErrors
+	}
+	return entries, nil
+}
+
+// parseAssignment recognizes environment lines. Matching quotes around the value are removed.
+// A time zone followed by more text is an entry with its own zone, e.g. `CRON_TZ=UTC 0 9 * * * cmd`.
+func parseAssignment(line string) (string, string, bool) {
+	match := assignment.FindStringSubmatch(line)
+	if match == nil {
+		return "", "", false
+	}
+
+	name, value := match[1], match[2]
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return name, value[1 : len(value)-1], true
+	}
+	if (name == "CRON_TZ" || name == "TZ") && strings.ContainsAny(value, " \t") {
+		return "", "", false
+	}
+	return name, value, true
+}
+
+func copyEnv(env map[string]string) map[string]string {
+	result := make(map[string]string, len(env))
+	for name, value := range env {
+		result[name] = value
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package cron
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCrontab(t *testing.T) {
+	crontab := strings.Join([]string{
+		"# m h dom mon dow command",
+		"",
+		"SHELL=/bin/bash",
+		"MAILTO = \"ops@example.com\"",
+		"*/15 0 1,15 * 1-5 /usr/bin/find",
+		"   ",
+		"  # indented comment",
+		"PATH='/usr/local/bin:/usr/bin'",
+		"@daily /usr/bin/backup # not a comment",
+		"CRON_TZ=Europe/Prague",
+		"0 9 * * * /usr/bin/report",
+		"TZ=UTC 0 9 * * * /usr/bin/utc",
+	}, "\n")
+
+	entries, err := ParseCrontab(strings.NewReader(crontab))
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+		return
+	}
+
+	expected := []struct {
+		line     int
+		command  string
+		location string
+		env      map[string]string
+	}{
+		{5, "/usr/bin/find", "", map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com"}},
+		{9, "/usr/bin/backup # not a comment", "", map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com", "PATH": "/usr/local/bin:/usr/bin"}},
+		{11, "/usr/bin/report", "Europe/Prague", map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com", "PATH": "/usr/local/bin:/usr/bin", "CRON_TZ": "Europe/Prague"}},
+		{12, "/usr/bin/utc", "UTC", map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com", "PATH": "/usr/local/bin:/usr/bin", "CRON_TZ": "Europe/Prague"}},
+	}
+	if len(entries) != len(expected) {
+		t.Errorf("expected %d entries, got: %d", len(expected), len(entries))
+		return
+	}
+	for i := range expected {
+		entry := entries[i]
+		if entry.Line != expected[i].line || entry.Schedule.Command != expected[i].command {
+			t.Errorf("expected line %d with command %v\nbut got line %d with command %v", expected[i].line, expected[i].command, entry.Line, entry.Schedule.Command)
+		}
+
+		location := ""
+		if entry.Schedule.Location != nil {
+			location = entry.Schedule.Location.String()
+		}
+		if location != expected[i].location {
+			t.Errorf("expected location: %v\nbut got: %v", expected[i].location, location)
+		}
+
+		if !reflect.DeepEqual(entry.Env, expected[i].env) {
+			t.Errorf("expected env: %v\nbut got: %v", expected[i].env, entry.Env)
+		}
+	}
+}
+
+func TestParseCrontabErrors(t *testing.T) {
+	crontab := strings.Join([]string{
+		"0 0 * * * /usr/bin/find",
+		"61 0 * * * /usr/bin/find",
+		"# fine",
+		"CRON_TZ=Mars/Olympus",
+		"0 0 * *",
+		"30 6 * * * /usr/bin/backup",
+	}, "\n")
+
+	entries, err := ParseCrontab(strings.NewReader(crontab))
+	expectedErr := strings.Join([]string{
+		"line 2: item `61` out of range in `minute`",
+		"line 4: invalid time zone `Mars/Olympus`",
+		"line 5: invalid number of sections",
+	}, "\n")
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("expected error: %v\nbut got: %v", expectedErr, err)
+	}
+
+	var lineErrors CrontabError
+	if !errors.As(err, &lineErrors) || len(lineErrors) != 3 || lineErrors[0].Line != 2 {
+		t.Errorf("expected CrontabError with 3 lines, got: %#v", err)
+	}
+
+	if len(entries) != 2 || entries[0].Line != 1 || entries[1].Line != 6 {
+		t.Errorf("expected entries of lines 1 and 6, got: %v", entries)
+	}
+}
+
+func TestParseCrontabErrorsAs(t *testing.T) {
+	_, err := ParseCrontab(strings.NewReader("0 0 * * * /usr/bin/find\n0 25 * * * /usr/bin/find"))
+
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 2 {
+		t.Errorf("expected a LineError of line 2, got: %#v", err)
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Code != CodeOutOfRange || parseErr.Token != "25" || parseErr.Offset != 2 {
+		t.Errorf("expected an out of range ParseError of `25` at 2, got: %#v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Describe returns an English description of the schedule, e.g. `*/15 0 1,15 * 1-5` gives
+// "Every 15 minutes, at 00:00 hour, on day 1 and 15 of the month, Monday through Friday".
+// Ranges and steps are described as written, not as expanded items.
+func (s *Schedule) Describe() string {
+	return s.DescribeIn(English)
+}
+
+// DescribeIn returns a description of the schedule in the language of the locale, see LookupLocale.
+func (s *Schedule) DescribeIn(locale Locale) string {
+	var clauses []string
+	switch {
+	case s.Reboot:
+		clauses = []string{locale.Phrase(PhraseStartup)}
+	case s.Every > 0:
+		clauses = []string{fmt.Sprintf(locale.Phrase(PhraseInterval), interval(locale, s.Every))}
+	default:
+		d := describer{schedule: s, locale: locale}
+		clauses = append(d.times(), d.days()...)
+	}
+	return locale.Sentence(clauses)
+}
+
+// interval describes a duration in whole hours, minutes and seconds, e.g. "1 hour and 30 minutes".
+func interval(locale Locale, every time.Duration) string {
+	units := []struct {
+		count       int
+		one, plural Phrase
+	}{
+		{int(every / time.Hour), PhraseHour, PhraseHours},
+		{int(every % time.Hour / time.Minute), PhraseMinute, PhraseMinutes},
+		{int(every % time.Minute / time.Second), PhraseSecond, PhraseSeconds},
+	}
+
+	var parts []string
+	for _, unit := range units {
+		switch unit.count {
+		case 0:
+		case 1:
+			parts = append(parts, fmt.Sprintf(locale.Phrase(unit.one), unit.count))
+		default:
+			parts = append(parts, fmt.Sprintf(locale.Phrase(unit.plural), unit.count))
+		}
+	}
+	return locale.List(parts)
+}
+
+type describer struct {
+	schedule *Schedule
+	locale   Locale
+}
+
+// times describes seconds, minutes and hours, as times of day when they are single values.
+func (d describer) times() (clauses []string) {
+	second, hasSecond := d.schedule.Field(Second)
+	minute, _ := d.schedule.Field(Minute)
+	hour, _ := d.schedule.Field(Hour)
+	seconds, minutes, hours := unitsOf(second), unitsOf(minute), unitsOf(hour)
+
+	if (!hasSecond || single(seconds)) && single(minutes) && singles(hours) {
+		var times []string
+		for _, unit := range hours {
+			times = append(times, d.clock(unit.Start, minutes[0].Start, seconds, hasSecond))
+		}
+		return []string{fmt.Sprintf(d.locale.Phrase(PhraseAt), d.locale.List(times))}
+	}
+
+	// Seconds are left out when the schedule fires at the start of a minute
+	everySecond := hasSecond && !(single(seconds) && seconds[0].Start == 0)
+	if everySecond {
+		if unrestricted(seconds) {
+			clauses = append(clauses, d.locale.Phrase(PhraseEverySecond))
+		} else {
+			clauses = append(clauses, d.field(Second, seconds, strconv.Itoa)...)
+		}
+	}
+	switch {
+	case unrestricted(minutes) && !everySecond:
+		clauses = append(clauses, d.locale.Phrase(PhraseEveryMinute))
+	case !unrestricted(minutes):
+		clauses = append(clauses, d.field(Minute, minutes, strconv.Itoa)...)
+	}
+	if !unrestricted(hours) {
+		clauses = append(clauses, d.field(Hour, hours, func(h int) string { return d.locale.Time(h, 0, 0, false) })...)
+	}
+	return clauses
+}
+
+// days describes day of month, month, day of week and year, each with its special days.
+func (d describer) days() (clauses []string) {
+	month := func(m int) string { return d.locale.Month(time.Month((m-1)%12 + 1)) }
+	weekday := func(w int) string { return d.locale.Weekday(time.Weekday(w % 7)) }
+
+	for _, label := range []string{DayOfMonth, Month, DayOfWeek, Year} {
+		field, ok := d.schedule.Field(label)
+		if !ok {
+			continue
+		}
+
+		value := strconv.Itoa
+		switch label {
+		case Month:
+			value = month
+		case DayOfWeek:
+			value = weekday
+		}
+		if units := unitsOf(field); !unrestricted(units) {
+			clauses = append(clauses, d.field(label, units, value)...)
+		}
+		for _, special := range field.Specials {
+			clauses = append(clauses, d.special(special, weekday))
+		}
+	}
+	return clauses
+}
+
+// field describes units of one field, stepped units and ranges with a phrase of their own each on their own,
+// the others as one list.
+func (d describer) field(label string, units []Unit, value func(int) string) (clauses []string) {
+	var values []string
+	for _, unit := range units {
+		switch {
+		case unit.Step > 0 && unit.Any:
+			clauses = append(clauses, fmt.Sprintf(d.locale.Phrase(stepPhrases[label]), unit.Step))
+		case unit.Step > 0:
+			every := fmt.Sprintf(d.locale.Phrase(stepPhrases[label]), unit.Step)
+			clauses = append(clauses, fmt.Sprintf(d.locale.Phrase(PhraseStepRange), every, value(unit.Start), value(unit.End)))
+		case unit.Start == unit.End:
+			values = append(values, value(unit.Start))
+		default:
+			if phrase, ok := rangePhrases[label]; ok {
+				clauses = append(clauses, fmt.Sprintf(d.locale.Phrase(phrase), value(unit.Start), value(unit.End)))
+				continue
+			}
+			values = append(values, fmt.Sprintf(d.locale.Phrase(PhraseThrough), value(unit.Start), value(unit.End)))
+		}
+	}
+	if len(values) > 0 {
+		clauses = append(clauses, fmt.Sprintf(d.locale.Phrase(listPhrases[label]), d.locale.List(values)))
+	}
+	return clauses
+}
+
+func (d describer) special(special Special, weekday func(int) string) string {
+	switch special.Kind {
+	case LastDay:
+		if special.Offset > 0 {
+			return fmt.Sprintf(d.locale.Phrase(PhraseDaysBeforeLastDay), special.Offset)
+		}
+		return d.locale.Phrase(PhraseLastDay)
+	case NearestWorkday:
+		return fmt.Sprintf(d.locale.Phrase(PhraseNearestWorkday), special.Value)
+	case LastWorkday:
+		return d.locale.Phrase(PhraseLastWorkday)
+	case LastWeekday:
+		return fmt.Sprintf(d.locale.Phrase(PhraseLastWeekday), weekday(special.Value))
+	case NthWeekday:
+		return fmt.Sprintf(d.locale.Phrase(PhraseNthWeekday), d.locale.Ordinal(special.Nth), weekday(special.Value))
+	}
+	return special.String()
+}
+
+// unitsOf returns units of a field, or its items one by one when the units are not known.
+func unitsOf(field Field) []Unit {
+	if len(field.Units) > 0 || len(field.Items) == 0 {
+		return field.Units
+	}
+
+	var units []Unit
+	for _, item := range field.Items {
+		units = append(units, Unit{Start: item, End: item})
+	}
+	return units
+}
+
+// unrestricted reports whether units stand for the whole field, as a plain `*` does.
+func unrestricted(units []Unit) bool {
+	return len(units) == 0 || (len(units) == 1 && units[0].Any && units[0].Step == 0)
+}
+
+func single(units []Unit) bool {
+	return len(units) == 1 && singles(units)
+}
+
+func singles(units []Unit) bool {
+	for _, unit := range units {
+		if unit.Any || unit.Step > 0 || unit.Start != unit.End {
+			return false
+		}
+	}
+	return len(units) > 0
+}
+
+// clock formats a time of day, with seconds when the schedule fires after the start of a minute.
+func (d describer) clock(hour, minute int, seconds []Unit, hasSecond bool) string {
+	if hasSecond && seconds[0].Start > 0 {
+		return d.locale.Time(hour, minute, seconds[0].Start, true)
+	}
+	return d.locale.Time(hour, minute, 0, false)
+}
@@ -0,0 +1,99 @@
+package cron
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	testCases := map[string]struct {
+		expression string
+		options    []Option
+		expected   string
+	}{
+		"Assigment": {
+			expression: `*/15 0 1,15 * 1-5 /usr/bin/find`,
+			expected:   "Every 15 minutes, at 00:00 hour, on day 1 and 15 of the month, Monday through Friday",
+		},
+		"Time of day": {
+			expression: `30 9 * * * /usr/bin/find`,
+			expected:   "At 09:30",
+		},
+		"Times of day": {
+			expression: `0 9,17 * * mon-fri /usr/bin/find`,
+			expected:   "At 09:00 and 17:00, Monday through Friday",
+		},
+		"Every minute": {
+			expression: `* * * * * /usr/bin/find`,
+			expected:   "Every minute",
+		},
+		"Step within range": {
+			expression: `5 8-17/2 * * * /usr/bin/find`,
+			expected:   "At minute 5 past the hour, every 2 hours from 08:00 through 17:00",
+		},
+		"Hour range": {
+			expression: `*/5 9-17,20 * * * /usr/bin/find`,
+			expected:   "Every 5 minutes, between 09:00 and 17:00, at 20:00 hour",
+		},
+		"Ranges": {
+			expression: `10-20,45 * * jan-mar * /usr/bin/find`,
+			expected:   "At minute 10 through 20 and 45 past the hour, only in January through March",
+		},
+		"Last day": {
+			expression: `0 0 L * * /usr/bin/find`,
+			expected:   "At 00:00, on the last day of the month",
+		},
+		"Special days": {
+			expression: `0 0 15W 1,6 5L,1#2 /usr/bin/find`,
+			expected:   "At 00:00, on the weekday nearest day 15 of the month, only in January and June, on the last Friday of the month, on the second Monday of the month",
+		},
+		"Reboot": {
+			expression: `@reboot /usr/bin/find`,
+			expected:   "At startup",
+		},
+		"Interval": {
+			expression: `@every 90m /usr/bin/find`,
+			expected:   "Every 1 hour and 30 minutes",
+		},
+		"Interval of seconds": {
+			expression: `@every 2h1s /usr/bin/find`,
+			expected:   "Every 2 hours and 1 second",
+		},
+		"Seconds": {
+			expression: `*/10 * * * * * /usr/bin/find`,
+			options:    []Option{WithSeconds()},
+			expected:   "Every 10 seconds",
+		},
+		"Time with seconds": {
+			expression: `30 0 12 * * * /usr/bin/find`,
+			options:    []Option{WithSeconds()},
+			expected:   "At 12:00:30",
+		},
+		"Jenkins whole week": {
+			expression: `* * * * 0-7`,
+			options:    []Option{WithDialect(DialectJenkins)},
+			expected:   "Every minute, Sunday through Saturday",
+		},
+		"Jenkins weekend": {
+			expression: `0 9 * * 6-7`,
+			options:    []Option{WithDialect(DialectJenkins)},
+			expected:   "At 09:00, Saturday through Sunday",
+		},
+		"Quartz": {
+			expression: `0 0 12 ? * WED 2025-2030`,
+			options:    []Option{WithQuartz()},
+			expected:   "At 12:00, Wednesday, only in 2025 through 2030",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression, testCase.options...)
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if got := schedule.Describe(); got != testCase.expected {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expected, got)
+			}
+		})
+	}
+}
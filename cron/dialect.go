@@ -0,0 +1,84 @@
+package cron
+
+import "github.com/gondo/cron-parser/internal/parser"
+
+// Dialect is a flavour of cron expressions: its slots, macros and day matching rule.
+// Allowed special characters, modifiers and the numbering of values are set per Slot.
+type Dialect = parser.Dialect
+
+// Slot describes one field of a dialect: its label, range, allowed characters and modifiers.
+type Slot = parser.Slot
+
+// Names maps lower case names to values of a Slot, e.g. "jan" and "january" to 1.
+type Names = parser.Names
+
+// MonthNames returns a copy of the English month names the built-in dialects accept.
+func MonthNames() Names {
+	return parser.MonthNames()
+}
+
+// DayOfWeekNames returns a copy of the English weekday names the built-in dialects accept, Sunday = 0.
+func DayOfWeekNames() Names {
+	return parser.DayOfWeekNames()
+}
+
+// Modifier is a set of special characters a Slot allows.
+type Modifier = parser.Modifier
+
+// Slot modifiers
+const (
+	ModifierLast       = parser.ModifierLast
+	ModifierWeekday    = parser.ModifierWeekday
+	ModifierNth        = parser.ModifierNth
+	ModifierHash       = parser.ModifierHash
+	ModifierRandom     = parser.ModifierRandom
+	ModifierLastOffset = parser.ModifierLastOffset
+)
+
+// DayRule tells how day of month and day of week combine.
+type DayRule = parser.DayRule
+
+// Day rules
+const (
+	// A day matching either fires, unless one of them is unrestricted, as in Vixie cron
+	DayRuleOr = parser.DayRuleOr
+	// A day has to match both
+	DayRuleAnd = parser.DayRuleAnd
+)
+
+// Built-in dialects
+const (
+	// Standard five fields and a command, the default
+	DialectVixie = parser.DialectVixie
+	// Vixie with a seconds field first
+	DialectSeconds = parser.DialectSeconds
+	// Numbers, lists and ranges only, no macros
+	DialectPOSIX = parser.DialectPOSIX
+	// Quartz scheduler, see WithQuartz
+	DialectQuartz = parser.DialectQuartz
+	// AWS EventBridge cron fields, a mandatory year and no command. Fields are accepted with or without
+	// the `cron(...)` wrapper EventBridge requires
+	DialectAWS = parser.DialectAWS
+	// Jenkins triggers, no command, `H` hashes the key set by WithKey and day of week 7 is Sunday as well
+	DialectJenkins = parser.DialectJenkins
+	// Vixie with random values `~`, see WithRand, and without `@every`
+	DialectOpenBSD = parser.DialectOpenBSD
+)
+
+// RegisterDialect makes a dialect available to WithDialect by its name.
+// Names are case insensitive and can not be registered twice, built-in ones included.
+// The dialect is copied, changing it later does not change the registered one.
+func RegisterDialect(dialect Dialect) error {
+	return parser.RegisterDialect(dialect)
+}
+
+// LookupDialect returns a copy of a registered dialect, e.g. to derive a custom one from it.
+// Changing the copy does not change the registered dialect.
+func LookupDialect(name string) (Dialect, bool) {
+	return parser.LookupDialect(name)
+}
+
+// Dialects returns sorted names of registered dialects.
+func Dialects() []string {
+	return parser.Dialects()
+}
@@ -0,0 +1,11 @@
+// Package cron parses cron expressions into schedules.
+//
+// The package is the public API of this module; the command line tool in
+// cmd/cron-parser is just one of its consumers. The API follows semantic
+// versioning: within a major version exported identifiers are neither
+// removed nor changed in an incompatible way, and the parsed field values
+// for a given expression stay the same. Version reports the current release.
+package cron
+
+// Version of the public API.
+const Version = "1.0.0"
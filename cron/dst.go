@@ -0,0 +1,78 @@
+package cron
+
+import "time"
+
+// DSTPolicy decides what happens to fire times affected by daylight saving changes.
+// The zero value behaves as Vixie cron.
+type DSTPolicy struct {
+	Gap     GapPolicy
+	Overlap OverlapPolicy
+}
+
+// GapPolicy applies to wall clock times skipped when clocks go forward.
+type GapPolicy int
+
+const (
+	// GapRunAfter fires once at the first minute after the gap.
+	GapRunAfter GapPolicy = iota
+	// GapSkip does not fire for wall clock times that do not exist.
+	GapSkip
+)
+
+// OverlapPolicy applies to wall clock times repeated when clocks go back.
+type OverlapPolicy int
+
+const (
+	// OverlapOnce fires only at the first occurrence of a repeated wall clock time
+	// when the hour field is fixed, schedules with an hour starting with `*` fire at both as Vixie cron does.
+	OverlapOnce OverlapPolicy = iota
+	// OverlapTwice fires at both occurrences.
+	OverlapTwice
+)
+
+// date works as time.Date but always returns the first time at which the wall clock
+// shows the given time or later, even if clocks went back or jumped over it.
+func date(year int, month time.Month, day, hour, minute int, loc *time.Location) time.Time {
+	want := time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	t := time.Date(year, month, day, hour, minute, 0, 0, loc)
+
+	// Inside a gap time.Date may pick either side of it
+	for wallClock(t).Before(want) {
+		t = t.Add(time.Minute)
+	}
+	for !wallClock(t.Add(-time.Minute)).Before(want) {
+		t = t.Add(-time.Minute)
+	}
+
+	if first, ok := firstOccurrence(t); ok {
+		return first
+	}
+	return t
+}
+
+// firstOccurrence returns an earlier time with the same wall clock, if there is one.
+func firstOccurrence(t time.Time) (time.Time, bool) {
+	_, offset := t.Zone()
+	// Zones do not change their offset twice within a few hours
+	_, before := t.Add(-12 * time.Hour).Zone()
+	if before <= offset {
+		return time.Time{}, false
+	}
+
+	earlier := t.Add(-time.Duration(before-offset) * time.Second)
+	if !wallClock(earlier).Equal(wallClock(t)) {
+		return time.Time{}, false
+	}
+	return earlier, true
+}
+
+// skipped returns wall clock times jumped over right before t, the interval is empty without a gap.
+func skipped(t time.Time, step time.Duration) (from, to time.Time) {
+	return wallClock(t.Add(-step)).Add(step), wallClock(t)
+}
+
+// wallClock returns the time as shown on a clock in its location, expressed in UTC.
+func wallClock(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
@@ -0,0 +1,51 @@
+package cron
+
+import "github.com/gondo/cron-parser/internal/parser"
+
+// ParseError is an invalid part of an expression: its field, the offending token, the byte offset
+// of the token in the expression and a code telling what is wrong. Parse returns it for invalid input,
+// ParseCrontab within a LineError, so it is found with errors.As:
+//
+//	var parseErr *cron.ParseError
+//	if errors.As(err, &parseErr) {
+//		fmt.Println(parseErr.Code, parseErr.Offset, parseErr.Token)
+//	}
+type ParseError = parser.ParseError
+
+// ParseErrors lists every invalid part of an expression, see WithAllErrors.
+// Each of them is found with errors.As as well.
+type ParseErrors = parser.ParseErrors
+
+// asParseErrors returns the parse errors err holds.
+func asParseErrors(err error) ParseErrors {
+	switch e := err.(type) {
+	case *ParseError:
+		return ParseErrors{e}
+	case ParseErrors:
+		return e
+	}
+	return nil
+}
+
+// ErrorCode tells what is wrong with an expression. Codes are stable, messages may change.
+type ErrorCode = parser.ErrorCode
+
+// Error codes
+const (
+	CodeSections     = parser.CodeSections
+	CodeUser         = parser.CodeUser
+	CodeMacro        = parser.CodeMacro
+	CodeDuration     = parser.CodeDuration
+	CodeRate         = parser.CodeRate
+	CodeCharacters   = parser.CodeCharacters
+	CodeToken        = parser.CodeToken
+	CodeQuestionMark = parser.CodeQuestionMark
+	CodeStep         = parser.CodeStep
+	CodeRangeStart   = parser.CodeRangeStart
+	CodeRangeEnd     = parser.CodeRangeEnd
+	CodeRangeOrder   = parser.CodeRangeOrder
+	CodeItem         = parser.CodeItem
+	CodeOutOfRange   = parser.CodeOutOfRange
+	CodeKey          = parser.CodeKey
+	CodeTimeZone     = parser.CodeTimeZone
+)
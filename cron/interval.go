@@ -0,0 +1,29 @@
+package cron
+
+import "time"
+
+// nextInterval returns the first multiple of every after the given time, counted from the Unix epoch.
+func nextInterval(every time.Duration, after time.Time) time.Time {
+	return time.Unix(0, (floorDiv(after.UnixNano(), int64(every))+1)*int64(every))
+}
+
+// prevInterval returns the last multiple of every before the given time, counted from the Unix epoch.
+func prevInterval(every time.Duration, before time.Time) time.Time {
+	return time.Unix(0, (ceilDiv(before.UnixNano(), int64(every))-1)*int64(every))
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
+
+func ceilDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a > 0 {
+		q++
+	}
+	return q
+}
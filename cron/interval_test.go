@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInterval(t *testing.T) {
+	testCases := map[string]struct {
+		every        time.Duration
+		from         string
+		expectedNext string
+		expectedPrev string
+	}{
+		"Aligned": {
+			every:        90 * time.Minute,
+			from:         "2021-01-01T01:30:00Z",
+			expectedNext: "2021-01-01T03:00:00Z",
+			expectedPrev: "2021-01-01T00:00:00Z",
+		},
+		"Between": {
+			every:        90 * time.Minute,
+			from:         "2021-01-01T01:00:00Z",
+			expectedNext: "2021-01-01T01:30:00Z",
+			expectedPrev: "2021-01-01T00:00:00Z",
+		},
+		"Before epoch": {
+			every:        time.Hour,
+			from:         "1969-12-31T22:30:00Z",
+			expectedNext: "1969-12-31T23:00:00Z",
+			expectedPrev: "1969-12-31T22:00:00Z",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			from := mustTime(t, testCase.from)
+
+			checkTime(t, testCase.expectedNext, nextInterval(testCase.every, from).UTC())
+			checkTime(t, testCase.expectedPrev, prevInterval(testCase.every, from).UTC())
+		})
+	}
+}
+
+func TestEvery(t *testing.T) {
+	schedule, err := Parse(`@every 1h30m /usr/bin/find`)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+		return
+	}
+
+	if schedule.Every != 90*time.Minute || schedule.Command != `/usr/bin/find` {
+		t.Errorf("expected interval schedule, got: %v", schedule)
+	}
+
+	from := mustTime(t, "2021-01-01T00:10:00Z")
+	got := collect(schedule.Upcoming(context.Background(), from, 3))
+	expected := []string{
+		"2021-01-01T01:30:00Z",
+		"2021-01-01T03:00:00Z",
+		"2021-01-01T04:30:00Z",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %v\nbut got: %v", expected, got)
+	}
+}
@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// Iterator lazily yields fire times, one per call of Next.
+//
+//	it := schedule.Between(ctx, from, to)
+//	for it.Next() {
+//		fmt.Println(it.Time())
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator struct {
+	ctx   context.Context
+	next  func(time.Time) time.Time
+	cur   time.Time
+	until time.Time // Zero means unbounded
+	left  int       // Negative means unlimited
+	err   error
+}
+
+// Between iterates over fire times in the half open interval [from, to).
+func (s *Schedule) Between(ctx context.Context, from, to time.Time) *Iterator {
+	return newIterator(ctx, s.Next, from.Add(-time.Nanosecond), to, -1)
+}
+
+// Upcoming iterates over at most n fire times after from, a negative n means no limit.
+func (s *Schedule) Upcoming(ctx context.Context, from time.Time, n int) *Iterator {
+	return newIterator(ctx, s.Next, from, time.Time{}, n)
+}
+
+func newIterator(ctx context.Context, next func(time.Time) time.Time, from, until time.Time, limit int) *Iterator {
+	return &Iterator{
+		ctx:   ctx,
+		next:  next,
+		cur:   from,
+		until: until,
+		left:  limit,
+	}
+}
+
+// Next advances to the following fire time. It returns false when the iteration is over
+// or the context is done, in which case Err reports the context error.
+func (it *Iterator) Next() bool {
+	if it.err != nil || it.left == 0 {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	t := it.next(it.cur)
+	if t.IsZero() || (!it.until.IsZero() && !t.Before(it.until)) {
+		it.left = 0
+		return false
+	}
+
+	it.cur = t
+	if it.left > 0 {
+		it.left--
+	}
+	return true
+}
+
+// Time returns the current fire time.
+func (it *Iterator) Time() time.Time {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
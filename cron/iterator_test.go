@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBetween(t *testing.T) {
+	testCases := map[string]struct {
+		expression string
+		from       string
+		to         string
+		expected   []string
+	}{
+		"Start included, end excluded": {
+			expression: `0 */6 * * * cmd`,
+			from:       "2021-01-01T00:00:00Z",
+			to:         "2021-01-02T00:00:00Z",
+			expected: []string{
+				"2021-01-01T00:00:00Z",
+				"2021-01-01T06:00:00Z",
+				"2021-01-01T12:00:00Z",
+				"2021-01-01T18:00:00Z",
+			},
+		},
+		"Empty window": {
+			expression: `0 0 1 * * cmd`,
+			from:       "2021-01-02T00:00:00Z",
+			to:         "2021-02-01T00:00:00Z",
+			expected:   nil,
+		},
+		"Never": {
+			expression: `0 0 30 2 * cmd`,
+			from:       "2021-01-01T00:00:00Z",
+			to:         "2022-01-01T00:00:00Z",
+			expected:   nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression)
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			it := schedule.Between(context.Background(), mustTime(t, testCase.from), mustTime(t, testCase.to))
+			got := collect(it)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expected, got)
+			}
+			if it.Err() != nil {
+				t.Errorf("expected no error, got: %v", it.Err())
+			}
+		})
+	}
+}
+
+func TestUpcoming(t *testing.T) {
+	testCases := map[string]struct {
+		expression string
+		from       string
+		n          int
+		expected   []string
+	}{
+		"Three": {
+			expression: `*/15 0 1,15 * 1-5 cmd`,
+			from:       "2021-01-01T00:20:00Z",
+			n:          3,
+			expected: []string{
+				"2021-01-01T00:30:00Z",
+				"2021-01-01T00:45:00Z",
+				"2021-01-04T00:00:00Z",
+			},
+		},
+		"None": {
+			expression: `* * * * * cmd`,
+			from:       "2021-01-01T00:00:00Z",
+			n:          0,
+			expected:   nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression)
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			got := collect(schedule.Upcoming(context.Background(), mustTime(t, testCase.from), testCase.n))
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestIteratorCancel(t *testing.T) {
+	schedule, err := Parse(`* * * * * cmd`)
+	if err != nil {
+		t.Errorf("expected no error, got: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := schedule.Upcoming(ctx, time.Now(), -1)
+
+	if !it.Next() {
+		t.Errorf("expected first time, got nothing")
+	}
+	cancel()
+	if it.Next() {
+		t.Errorf("expected iteration to stop after cancel")
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("expected error: %v\nbut got: %v", context.Canceled, it.Err())
+	}
+}
+
+func collect(it *Iterator) (times []string) {
+	for it.Next() {
+		times = append(times, it.Time().Format(time.RFC3339))
+	}
+	return times
+}
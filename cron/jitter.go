@@ -0,0 +1,27 @@
+package cron
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// Jitter delays every fire time of a schedule by the same offset below Max, derived from Key.
+// Jobs sharing an expression but not a key, e.g. a host name, spread their runs over the window
+// instead of all starting at once.
+type Jitter struct {
+	Max time.Duration
+	Key string
+}
+
+// Offset returns how much fire times are delayed, whole seconds below Max.
+// The same key and maximum always give the same offset.
+func (j Jitter) Offset() time.Duration {
+	seconds := uint64(j.Max / time.Second)
+	if seconds == 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(j.Key))
+	return time.Duration(h.Sum64()%seconds) * time.Second
+}
@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitter(t *testing.T) {
+	testCases := map[string]struct {
+		expression string
+		max        time.Duration
+		key        string
+		backward   bool
+		from       string
+		expected   string
+	}{
+		"Hourly": {
+			expression: `0 * * * * /usr/bin/find`,
+			max:        10 * time.Minute,
+			key:        "host-1",
+			from:       "2021-01-01T10:00:00Z",
+			expected:   "2021-01-01T10:04:37Z",
+		},
+		"Within offset": {
+			expression: `0 * * * * /usr/bin/find`,
+			max:        10 * time.Minute,
+			key:        "host-1",
+			from:       "2021-01-01T10:03:00Z",
+			expected:   "2021-01-01T10:04:37Z",
+		},
+		"After delayed run": {
+			expression: `0 * * * * /usr/bin/find`,
+			max:        10 * time.Minute,
+			key:        "host-1",
+			from:       "2021-01-01T10:04:37Z",
+			expected:   "2021-01-01T11:04:37Z",
+		},
+		"Other key": {
+			expression: `0 * * * * /usr/bin/find`,
+			max:        10 * time.Minute,
+			key:        "host-2",
+			from:       "2021-01-01T10:00:00Z",
+			expected:   "2021-01-01T10:04:04Z",
+		},
+		"Prev": {
+			expression: `0 * * * * /usr/bin/find`,
+			max:        10 * time.Minute,
+			key:        "host-1",
+			backward:   true,
+			from:       "2021-01-01T10:04:00Z",
+			expected:   "2021-01-01T09:04:37Z",
+		},
+		"No jitter": {
+			expression: `0 * * * * /usr/bin/find`,
+			key:        "host-1",
+			from:       "2021-01-01T10:00:00Z",
+			expected:   "2021-01-01T11:00:00Z",
+		},
+		"Every": {
+			expression: `@every 1h /usr/bin/find`,
+			max:        10 * time.Minute,
+			key:        "host-1",
+			from:       "2021-01-01T10:00:00Z",
+			expected:   "2021-01-01T10:04:37Z",
+		},
+		"Never": {
+			expression: `0 0 30 2 * /usr/bin/find`,
+			max:        10 * time.Minute,
+			key:        "host-1",
+			from:       "2021-01-01T10:00:00Z",
+			expected:   "",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression, WithJitter(testCase.max, testCase.key))
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			var got time.Time
+			if testCase.backward {
+				got = schedule.Prev(mustTime(t, testCase.from))
+			} else {
+				got = schedule.Next(mustTime(t, testCase.from))
+			}
+			checkTime(t, testCase.expected, got)
+		})
+	}
+
+	if _, err := Parse(`0 * * * * /usr/bin/find`, WithJitter(-time.Minute, "host-1")); err == nil || err.Error() != "negative jitter `-1m0s`" {
+		t.Errorf("expected error: negative jitter `-1m0s`\nbut got: %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Locale provides the words of descriptions in one language, see Schedule.DescribeIn.
+type Locale interface {
+	// Phrase returns the fmt pattern of a phrase, arguments can be reordered with `%[2]s`.
+	Phrase(phrase Phrase) string
+	// Time formats a time of day, the seconds only when withSeconds is set.
+	Time(hour, minute, second int, withSeconds bool) string
+	Month(month time.Month) string
+	Weekday(day time.Weekday) string
+	// Ordinal names an occurrence 1-5 within a month, e.g. "second".
+	Ordinal(n int) string
+	// List joins the values of one field, e.g. "1, 2 and 3".
+	List(values []string) string
+	// Sentence joins the clauses of a description.
+	Sentence(clauses []string) string
+}
+
+// Phrase is a part of a description a Locale translates.
+type Phrase int
+
+// Phrases and their arguments, e.g. `on day %s of the month` with a list of days in English
+const (
+	PhraseEverySecond Phrase = iota + 1 // No argument
+	PhraseEveryMinute                   // No argument
+
+	PhraseSecondList     // List of seconds
+	PhraseMinuteList     // List of minutes
+	PhraseHourList       // List of hours formatted as times
+	PhraseDayOfMonthList // List of days
+	PhraseMonthList      // List of month names
+	PhraseDayOfWeekList  // List of weekday names
+	PhraseYearList       // List of years
+
+	PhraseSecondStep     // Step
+	PhraseMinuteStep     // Step
+	PhraseHourStep       // Step
+	PhraseDayOfMonthStep // Step
+	PhraseMonthStep      // Step
+	PhraseDayOfWeekStep  // Step
+	PhraseYearStep       // Step
+
+	PhraseStepRange // Step phrase, start and end of its range
+	PhraseThrough   // Start and end of a range
+	PhraseAt        // List of times
+
+	PhraseStartup  // No argument
+	PhraseInterval // Interval as a list of hours, minutes and seconds
+
+	PhraseLastDay           // No argument
+	PhraseDaysBeforeLastDay // Number of days
+	PhraseNearestWorkday    // Day of month
+	PhraseLastWorkday       // No argument
+	PhraseLastWeekday       // Weekday name
+	PhraseNthWeekday        // Ordinal and weekday name
+
+	PhraseHour    // 1, the hours of an interval
+	PhraseHours   // Number of hours
+	PhraseMinute  // 1, the minutes of an interval
+	PhraseMinutes // Number of minutes
+	PhraseSecond  // 1, the seconds of an interval
+	PhraseSeconds // Number of seconds
+
+	PhraseHourRange // Start and end of a range of hours formatted as times
+)
+
+// Phrases of field lists and steps by field label
+var (
+	listPhrases = map[string]Phrase{
+		Second:     PhraseSecondList,
+		Minute:     PhraseMinuteList,
+		Hour:       PhraseHourList,
+		DayOfMonth: PhraseDayOfMonthList,
+		Month:      PhraseMonthList,
+		DayOfWeek:  PhraseDayOfWeekList,
+		Year:       PhraseYearList,
+	}
+	stepPhrases = map[string]Phrase{
+		Second:     PhraseSecondStep,
+		Minute:     PhraseMinuteStep,
+		Hour:       PhraseHourStep,
+		DayOfMonth: PhraseDayOfMonthStep,
+		Month:      PhraseMonthStep,
+		DayOfWeek:  PhraseDayOfWeekStep,
+		Year:       PhraseYearStep,
+	}
+	// Ranges described on their own rather than in the list of their field
+	rangePhrases = map[string]Phrase{
+		Hour: PhraseHourRange,
+	}
+)
+
+var locales = struct {
+	sync.RWMutex
+	tags map[string]Locale
+}{
+	tags: map[string]Locale{},
+}
+
+func init() {
+	for tag, locale := range bundledLocales {
+		locales.tags[tag] = locale
+	}
+}
+
+// RegisterLocale makes a locale available by its tag, e.g. "pt-BR". Tags are case insensitive
+// and can not be registered twice, bundled ones included.
+func RegisterLocale(tag string, locale Locale) error {
+	if tag == "" || locale == nil {
+		return errors.New("locale tag or locale missing")
+	}
+
+	locales.Lock()
+	defer locales.Unlock()
+
+	key := strings.ToLower(tag)
+	if _, ok := locales.tags[key]; ok {
+		return errors.New(fmt.Sprintf("locale `%s` already registered", tag))
+	}
+	locales.tags[key] = locale
+	return nil
+}
+
+// LookupLocale returns a registered locale, e.g. LookupLocale("de").
+func LookupLocale(tag string) (Locale, bool) {
+	locales.RLock()
+	defer locales.RUnlock()
+
+	locale, ok := locales.tags[strings.ToLower(tag)]
+	return locale, ok
+}
+
+// Locales returns sorted tags of registered locales.
+func Locales() (tags []string) {
+	locales.RLock()
+	defer locales.RUnlock()
+
+	for tag := range locales.tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// phrasebook is a Locale of bundled translations.
+type phrasebook struct {
+	phrases  map[Phrase]string
+	months   []string
+	weekdays []string
+	ordinals []string
+	// Last separator of a list, e.g. " and "
+	and string
+	// Separator of clauses and of other list values
+	comma      string
+	capitalize bool
+	clock      func(hour, minute, second int, withSeconds bool) string
+}
+
+func (p phrasebook) Phrase(phrase Phrase) string {
+	return p.phrases[phrase]
+}
+
+func (p phrasebook) Time(hour, minute, second int, withSeconds bool) string {
+	return p.clock(hour, minute, second, withSeconds)
+}
+
+func (p phrasebook) Month(month time.Month) string {
+	return p.months[month-1]
+}
+
+func (p phrasebook) Weekday(day time.Weekday) string {
+	return p.weekdays[day]
+}
+
+func (p phrasebook) Ordinal(n int) string {
+	return p.ordinals[n-1]
+}
+
+func (p phrasebook) List(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return strings.Join(values[:len(values)-1], p.comma) + p.and + values[len(values)-1]
+}
+
+func (p phrasebook) Sentence(clauses []string) string {
+	sentence := strings.Join(clauses, p.comma)
+	if !p.capitalize {
+		return sentence
+	}
+	r, size := utf8.DecodeRuneInString(sentence)
+	return string(unicode.ToUpper(r)) + sentence[size:]
+}
+
+// clock24 formats times as 09:30 or 09:30:15.
+func clock24(hour, minute, second int, withSeconds bool) string {
+	if withSeconds {
+		return fmt.Sprintf("%02d:%02d:%02d", hour, minute, second)
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
+
+// clock12 formats times as 9:30 AM or 9:30:15 PM.
+func clock12(hour, minute, second int, withSeconds bool) string {
+	t := time.Date(0, 1, 1, hour, minute, second, 0, time.UTC)
+	if withSeconds {
+		return t.Format("3:04:05 PM")
+	}
+	return t.Format("3:04 PM")
+}
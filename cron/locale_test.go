@@ -0,0 +1,158 @@
+package cron
+
+import "testing"
+
+func TestDescribeIn(t *testing.T) {
+	testCases := map[string]struct {
+		expression string
+		tag        string
+		expected   string
+	}{
+		"English 12-hour clock": {
+			expression: `0 9,17 * * mon-fri /usr/bin/find`,
+			tag:        "en-US",
+			expected:   "At 9:00 AM and 5:00 PM, Monday through Friday",
+		},
+		"German": {
+			expression: `*/15 0 1,15 * 1-5 /usr/bin/find`,
+			tag:        "de",
+			expected:   "Alle 15 Minuten, um 00:00 Uhr, an Tag 1 und 15 des Monats, Montag bis Freitag",
+		},
+		"French": {
+			expression: `0 0 L jan,jun * /usr/bin/find`,
+			tag:        "fr",
+			expected:   "À 00:00, le dernier jour du mois, uniquement les mois de janvier et juin",
+		},
+		"Spanish": {
+			expression: `5 8-17/2 * * * /usr/bin/find`,
+			tag:        "es",
+			expected:   "En el minuto 5 de cada hora, cada 2 horas de 08:00 a 17:00",
+		},
+		"French ranges": {
+			expression: `5-10/2 * * jan-mar mon-fri /usr/bin/find`,
+			tag:        "fr",
+			expected:   "Toutes les 2 minutes de 5 à 10, uniquement les mois de janvier à mars, lundi à vendredi",
+		},
+		"Spanish ranges": {
+			expression: `5-10/2 * * jan-mar mon-fri /usr/bin/find`,
+			tag:        "es",
+			expected:   "Cada 2 minutos de 5 a 10, solo en los meses de enero a marzo, lunes a viernes",
+		},
+		"English hour range": {
+			expression: `0 9-17 * * * /usr/bin/find`,
+			tag:        "en-US",
+			expected:   "At minute 0 past the hour, between 9:00 AM and 5:00 PM",
+		},
+		"German hour range": {
+			expression: `0 9-17 * * * /usr/bin/find`,
+			tag:        "de",
+			expected:   "In Minute 0 nach der vollen Stunde, zwischen 09:00 und 17:00 Uhr",
+		},
+		"French hour range": {
+			expression: `0 9-17 * * * /usr/bin/find`,
+			tag:        "fr",
+			expected:   "À la minute 0 de chaque heure, de 09:00 à 17:00",
+		},
+		"Spanish hour range": {
+			expression: `0 9-17 * * * /usr/bin/find`,
+			tag:        "es",
+			expected:   "En el minuto 0 de cada hora, de 09:00 a 17:00",
+		},
+		"Japanese hour range": {
+			expression: `0 9-17 * * * /usr/bin/find`,
+			tag:        "ja",
+			expected:   "毎時0分、9時0分から17時0分まで",
+		},
+		"Japanese": {
+			expression: `0 0 * * 1#2 /usr/bin/find`,
+			tag:        "ja",
+			expected:   "0時0分、毎月第2月曜日",
+		},
+		"Japanese interval": {
+			expression: `@every 1h30m /usr/bin/find`,
+			tag:        "ja",
+			expected:   "1時間と30分ごと",
+		},
+		"Tag case": {
+			expression: `@reboot /usr/bin/find`,
+			tag:        "DE",
+			expected:   "Beim Systemstart",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression)
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			locale, ok := LookupLocale(testCase.tag)
+			if !ok {
+				t.Errorf("expected locale `%s` to be registered", testCase.tag)
+				return
+			}
+
+			if got := schedule.DescribeIn(locale); got != testCase.expected {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	testCases := map[string]struct {
+		tag         string
+		locale      Locale
+		expectedErr string
+	}{
+		"Custom": {
+			tag:    "en-GB",
+			locale: English,
+		},
+
+		// Errors
+
+		"Bundled": {
+			tag:         "De",
+			locale:      English,
+			expectedErr: "locale `De` already registered",
+		},
+		"No tag": {
+			locale:      English,
+			expectedErr: "locale tag or locale missing",
+		},
+		"No locale": {
+			tag:         "pt",
+			expectedErr: "locale tag or locale missing",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := RegisterLocale(testCase.tag, testCase.locale)
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if _, ok := LookupLocale("en-gb"); !ok {
+				t.Errorf("expected locale `en-gb` to be registered")
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package cron
+
+import "fmt"
+
+// Bundled locales by lower case tag
+var bundledLocales = map[string]Locale{
+	"en":    English,
+	"en-us": englishUS,
+	"de":    german,
+	"fr":    french,
+	"es":    spanish,
+	"ja":    japanese,
+}
+
+// English is the locale of Describe, with a 24-hour clock. The "en-US" locale uses a 12-hour one.
+var English Locale = english
+
+var english = phrasebook{
+	phrases: map[Phrase]string{
+		PhraseEverySecond: "every second",
+		PhraseEveryMinute: "every minute",
+
+		PhraseSecondList:     "at second %s",
+		PhraseMinuteList:     "at minute %s past the hour",
+		PhraseHourList:       "at %s hour",
+		PhraseDayOfMonthList: "on day %s of the month",
+		PhraseMonthList:      "only in %s",
+		PhraseDayOfWeekList:  "%s",
+		PhraseYearList:       "only in %s",
+
+		PhraseSecondStep:     "every %d seconds",
+		PhraseMinuteStep:     "every %d minutes",
+		PhraseHourStep:       "every %d hours",
+		PhraseDayOfMonthStep: "every %d days",
+		PhraseMonthStep:      "every %d months",
+		PhraseDayOfWeekStep:  "every %d days of the week",
+		PhraseYearStep:       "every %d years",
+
+		PhraseStepRange: "%s from %s through %s",
+		PhraseThrough:   "%s through %s",
+		PhraseAt:        "at %s",
+
+		PhraseStartup:  "at startup",
+		PhraseInterval: "every %s",
+
+		PhraseLastDay:           "on the last day of the month",
+		PhraseDaysBeforeLastDay: "%d days before the last day of the month",
+		PhraseNearestWorkday:    "on the weekday nearest day %d of the month",
+		PhraseLastWorkday:       "on the last weekday of the month",
+		PhraseLastWeekday:       "on the last %s of the month",
+		PhraseNthWeekday:        "on the %s %s of the month",
+
+		PhraseHour:    "%d hour",
+		PhraseHours:   "%d hours",
+		PhraseMinute:  "%d minute",
+		PhraseMinutes: "%d minutes",
+		PhraseSecond:  "%d second",
+		PhraseSeconds: "%d seconds",
+
+		PhraseHourRange: "between %s and %s",
+	},
+	months:     []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	weekdays:   []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	ordinals:   []string{"first", "second", "third", "fourth", "fifth"},
+	and:        " and ",
+	comma:      ", ",
+	capitalize: true,
+	clock:      clock24,
+}
+
+var englishUS = func() phrasebook {
+	locale := english
+	locale.clock = clock12
+	return locale
+}()
+
+var german = phrasebook{
+	phrases: map[Phrase]string{
+		PhraseEverySecond: "jede Sekunde",
+		PhraseEveryMinute: "jede Minute",
+
+		PhraseSecondList:     "in Sekunde %s",
+		PhraseMinuteList:     "in Minute %s nach der vollen Stunde",
+		PhraseHourList:       "um %s Uhr",
+		PhraseDayOfMonthList: "an Tag %s des Monats",
+		PhraseMonthList:      "nur im %s",
+		PhraseDayOfWeekList:  "%s",
+		PhraseYearList:       "nur im Jahr %s",
+
+		PhraseSecondStep:     "alle %d Sekunden",
+		PhraseMinuteStep:     "alle %d Minuten",
+		PhraseHourStep:       "alle %d Stunden",
+		PhraseDayOfMonthStep: "alle %d Tage",
+		PhraseMonthStep:      "alle %d Monate",
+		PhraseDayOfWeekStep:  "alle %d Wochentage",
+		PhraseYearStep:       "alle %d Jahre",
+
+		PhraseStepRange: "%s von %s bis %s",
+		PhraseThrough:   "%s bis %s",
+		PhraseAt:        "um %s",
+
+		PhraseStartup:  "beim Systemstart",
+		PhraseInterval: "alle %s",
+
+		PhraseLastDay:           "am letzten Tag des Monats",
+		PhraseDaysBeforeLastDay: "%d Tage vor dem letzten Tag des Monats",
+		PhraseNearestWorkday:    "am Werktag, der dem %d. des Monats am nächsten liegt",
+		PhraseLastWorkday:       "am letzten Werktag des Monats",
+		PhraseLastWeekday:       "am letzten %s des Monats",
+		PhraseNthWeekday:        "am %s %s des Monats",
+
+		PhraseHour:    "%d Stunde",
+		PhraseHours:   "%d Stunden",
+		PhraseMinute:  "%d Minute",
+		PhraseMinutes: "%d Minuten",
+		PhraseSecond:  "%d Sekunde",
+		PhraseSeconds: "%d Sekunden",
+
+		PhraseHourRange: "zwischen %s und %s Uhr",
+	},
+	months:     []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+	weekdays:   []string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	ordinals:   []string{"ersten", "zweiten", "dritten", "vierten", "fünften"},
+	and:        " und ",
+	comma:      ", ",
+	capitalize: true,
+	clock:      clock24,
+}
+
+var french = phrasebook{
+	phrases: map[Phrase]string{
+		PhraseEverySecond: "chaque seconde",
+		PhraseEveryMinute: "chaque minute",
+
+		PhraseSecondList:     "à la seconde %s",
+		PhraseMinuteList:     "à la minute %s de chaque heure",
+		PhraseHourList:       "à %s",
+		PhraseDayOfMonthList: "le jour %s du mois",
+		PhraseMonthList:      "uniquement les mois de %s",
+		PhraseDayOfWeekList:  "%s",
+		PhraseYearList:       "uniquement les années %s",
+
+		PhraseSecondStep:     "toutes les %d secondes",
+		PhraseMinuteStep:     "toutes les %d minutes",
+		PhraseHourStep:       "toutes les %d heures",
+		PhraseDayOfMonthStep: "tous les %d jours",
+		PhraseMonthStep:      "tous les %d mois",
+		PhraseDayOfWeekStep:  "tous les %d jours de la semaine",
+		PhraseYearStep:       "tous les %d ans",
+
+		PhraseStepRange: "%s de %s à %s",
+		PhraseThrough:   "%s à %s",
+		PhraseAt:        "à %s",
+
+		PhraseStartup:  "au démarrage",
+		PhraseInterval: "à intervalles de %s",
+
+		PhraseLastDay:           "le dernier jour du mois",
+		PhraseDaysBeforeLastDay: "%d jours avant le dernier jour du mois",
+		PhraseNearestWorkday:    "le jour ouvré le plus proche du %d du mois",
+		PhraseLastWorkday:       "le dernier jour ouvré du mois",
+		PhraseLastWeekday:       "le dernier %s du mois",
+		PhraseNthWeekday:        "le %s %s du mois",
+
+		PhraseHour:    "%d heure",
+		PhraseHours:   "%d heures",
+		PhraseMinute:  "%d minute",
+		PhraseMinutes: "%d minutes",
+		PhraseSecond:  "%d seconde",
+		PhraseSeconds: "%d secondes",
+
+		PhraseHourRange: "de %s à %s",
+	},
+	months:     []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	weekdays:   []string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	ordinals:   []string{"premier", "deuxième", "troisième", "quatrième", "cinquième"},
+	and:        " et ",
+	comma:      ", ",
+	capitalize: true,
+	clock:      clock24,
+}
+
+var spanish = phrasebook{
+	phrases: map[Phrase]string{
+		PhraseEverySecond: "cada segundo",
+		PhraseEveryMinute: "cada minuto",
+
+		PhraseSecondList:     "en el segundo %s",
+		PhraseMinuteList:     "en el minuto %s de cada hora",
+		PhraseHourList:       "a las %s",
+		PhraseDayOfMonthList: "el día %s del mes",
+		PhraseMonthList:      "solo en los meses de %s",
+		PhraseDayOfWeekList:  "%s",
+		PhraseYearList:       "solo en los años %s",
+
+		PhraseSecondStep:     "cada %d segundos",
+		PhraseMinuteStep:     "cada %d minutos",
+		PhraseHourStep:       "cada %d horas",
+		PhraseDayOfMonthStep: "cada %d días",
+		PhraseMonthStep:      "cada %d meses",
+		PhraseDayOfWeekStep:  "cada %d días de la semana",
+		PhraseYearStep:       "cada %d años",
+
+		PhraseStepRange: "%s de %s a %s",
+		PhraseThrough:   "%s a %s",
+		PhraseAt:        "a las %s",
+
+		PhraseStartup:  "al iniciar el sistema",
+		PhraseInterval: "cada %s",
+
+		PhraseLastDay:           "el último día del mes",
+		PhraseDaysBeforeLastDay: "%d días antes del último día del mes",
+		PhraseNearestWorkday:    "el día laborable más cercano al día %d del mes",
+		PhraseLastWorkday:       "el último día laborable del mes",
+		PhraseLastWeekday:       "el último %s del mes",
+		PhraseNthWeekday:        "el %s %s del mes",
+
+		PhraseHour:    "%d hora",
+		PhraseHours:   "%d horas",
+		PhraseMinute:  "%d minuto",
+		PhraseMinutes: "%d minutos",
+		PhraseSecond:  "%d segundo",
+		PhraseSeconds: "%d segundos",
+
+		PhraseHourRange: "de %s a %s",
+	},
+	months:     []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	weekdays:   []string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	ordinals:   []string{"primer", "segundo", "tercer", "cuarto", "quinto"},
+	and:        " y ",
+	comma:      ", ",
+	capitalize: true,
+	clock:      clock24,
+}
+
+var japanese = phrasebook{
+	phrases: map[Phrase]string{
+		PhraseEverySecond: "毎秒",
+		PhraseEveryMinute: "毎分",
+
+		PhraseSecondList:     "%s秒",
+		PhraseMinuteList:     "毎時%s分",
+		PhraseHourList:       "%s台",
+		PhraseDayOfMonthList: "毎月%s日",
+		PhraseMonthList:      "%sのみ",
+		PhraseDayOfWeekList:  "%s",
+		PhraseYearList:       "%s年のみ",
+
+		PhraseSecondStep:     "%d秒ごと",
+		PhraseMinuteStep:     "%d分ごと",
+		PhraseHourStep:       "%d時間ごと",
+		PhraseDayOfMonthStep: "%d日ごと",
+		PhraseMonthStep:      "%dか月ごと",
+		PhraseDayOfWeekStep:  "%d曜日ごと",
+		PhraseYearStep:       "%d年ごと",
+
+		PhraseStepRange: "%[2]sから%[3]sまで%[1]s",
+		PhraseThrough:   "%sから%sまで",
+		PhraseAt:        "%s",
+
+		PhraseStartup:  "起動時",
+		PhraseInterval: "%sごと",
+
+		PhraseLastDay:           "毎月最終日",
+		PhraseDaysBeforeLastDay: "毎月最終日の%d日前",
+		PhraseNearestWorkday:    "毎月%d日に最も近い平日",
+		PhraseLastWorkday:       "毎月最終平日",
+		PhraseLastWeekday:       "毎月最終%s",
+		PhraseNthWeekday:        "毎月第%s%s",
+
+		PhraseHour:    "%d時間",
+		PhraseHours:   "%d時間",
+		PhraseMinute:  "%d分",
+		PhraseMinutes: "%d分",
+		PhraseSecond:  "%d秒",
+		PhraseSeconds: "%d秒",
+
+		PhraseHourRange: "%sから%sまで",
+	},
+	months:   []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+	weekdays: []string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+	ordinals: []string{"1", "2", "3", "4", "5"},
+	and:      "と",
+	comma:    "、",
+	clock:    clockJapanese,
+}
+
+// clockJapanese formats times as 9時30分 or 9時30分15秒.
+func clockJapanese(hour, minute, second int, withSeconds bool) string {
+	if withSeconds {
+		return fmt.Sprintf("%d時%d分%d秒", hour, minute, second)
+	}
+	return fmt.Sprintf("%d時%d分", hour, minute)
+}
@@ -0,0 +1,312 @@
+package cron
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gondo/cron-parser/internal/parser"
+)
+
+// How far Next and Prev look before giving up on an expression that never fires, e.g. `0 0 30 2 *`.
+const searchYears = 30
+
+// Next returns the first time after the given one at which the schedule fires.
+// Day of month and day of week combine as the schedule DayRule tells: with DayRuleOr a day matching
+// either of them fires when both are restricted, otherwise both have to match, with DayRuleAnd both always do.
+// The zero time is returned when the schedule never fires, which is always the case for @reboot
+// and Incomplete schedules. Schedules with Every set fire at multiples of the interval counted
+// from the Unix epoch, e.g. @every 90m at 00:00, 01:30, 03:00 UTC.
+//
+// Times are computed in the schedule Location, or in the location of after when it is not set.
+// Daylight saving changes are handled as set by the schedule DST policy.
+// With Jitter set every fire time is delayed by its offset.
+func (s *Schedule) Next(after time.Time) time.Time {
+	offset := s.Jitter.Offset()
+	return delay(s.next(after.Add(-offset)), offset)
+}
+
+func (s *Schedule) next(after time.Time) time.Time {
+	if s.Reboot || s.Incomplete {
+		return time.Time{}
+	}
+	if s.Every > 0 {
+		return nextInterval(s.Every, after).In(s.location(after))
+	}
+
+	m := s.matcher()
+	t := after.In(s.location(after)).Truncate(m.step).Add(m.step)
+	limit := t.AddDate(searchYears, 0, 0)
+	if len(m.years) > 0 {
+		limit = date(m.years[len(m.years)-1]+1, 1, 1, 0, 0, t.Location())
+	}
+
+	for t.Before(limit) {
+		if s.firesAfterGap(m, t) {
+			return t
+		}
+
+		y, mo, d := t.Date()
+		h, loc := t.Hour(), t.Location()
+
+		switch {
+		case !m.hasYear(y):
+			next, ok := m.nextYear(y)
+			if !ok {
+				return time.Time{}
+			}
+			t = date(next, 1, 1, 0, 0, loc)
+		case !m.month.has(int(mo)):
+			t = date(y, mo+1, 1, 0, 0, loc)
+		case !m.day(t):
+			t = date(y, mo, d+1, 0, 0, loc)
+		case !m.hour.has(h):
+			t = date(y, mo, d, h+1, 0, loc)
+		case !m.minute.has(t.Minute()):
+			t = t.Add(time.Minute - time.Duration(t.Second())*time.Second)
+		case !m.second.has(t.Second()) || s.skipsOverlap(m, t):
+			t = t.Add(m.step)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Prev returns the last time before the given one at which the schedule fired.
+// Day matching, locations, daylight saving changes and jitter follow the same rules as Next.
+// The zero time is returned when there is no such time.
+func (s *Schedule) Prev(before time.Time) time.Time {
+	offset := s.Jitter.Offset()
+	return delay(s.prev(before.Add(-offset)), offset)
+}
+
+func (s *Schedule) prev(before time.Time) time.Time {
+	if s.Reboot || s.Incomplete {
+		return time.Time{}
+	}
+	if s.Every > 0 {
+		return prevInterval(s.Every, before).In(s.location(before))
+	}
+
+	m := s.matcher()
+	t := before.In(s.location(before)).Truncate(m.step)
+	if !t.Before(before) {
+		t = t.Add(-m.step)
+	}
+	limit := t.AddDate(-searchYears, 0, 0)
+	if len(m.years) > 0 {
+		limit = date(m.years[0], 1, 1, 0, 0, t.Location()).Add(-time.Nanosecond)
+	}
+
+	for t.After(limit) {
+		if s.firesAfterGap(m, t) {
+			return t
+		}
+
+		y, mo, d := t.Date()
+		h, loc := t.Hour(), t.Location()
+
+		switch {
+		case !m.hasYear(y):
+			prev, ok := m.prevYear(y)
+			if !ok {
+				return time.Time{}
+			}
+			t = back(t, date(prev+1, 1, 1, 0, 0, loc), m.step)
+		case !m.month.has(int(mo)):
+			t = back(t, date(y, mo, 1, 0, 0, loc), m.step)
+		case !m.day(t):
+			t = back(t, date(y, mo, d, 0, 0, loc), m.step)
+		case !m.hour.has(h):
+			t = back(t, date(y, mo, d, h, 0, loc), m.step)
+		case !m.minute.has(t.Minute()):
+			t = back(t, t.Add(-time.Duration(t.Second())*time.Second), m.step)
+		case !m.second.has(t.Second()) || s.skipsOverlap(m, t):
+			t = t.Add(-m.step)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// delay shifts a fire time by the jitter offset, the zero time stays as it is.
+func delay(t time.Time, offset time.Duration) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.Add(offset)
+}
+
+// back moves to the start of the current period, or before it when already there.
+// Stopping at the start makes sure a fire time right after a gap is not jumped over.
+func back(t, start time.Time, step time.Duration) time.Time {
+	if start.Before(t) {
+		return start
+	}
+	return t.Add(-step)
+}
+
+func (s *Schedule) location(t time.Time) *time.Location {
+	if s.Location != nil {
+		return s.Location
+	}
+	return t.Location()
+}
+
+// firesAfterGap reports whether any wall clock time skipped right before t matches.
+func (s *Schedule) firesAfterGap(m matcher, t time.Time) bool {
+	if s.DST.Gap != GapRunAfter {
+		return false
+	}
+
+	from, to := skipped(t, m.step)
+	for w := from; w.Before(to); w = w.Add(m.step) {
+		if m.matches(w) {
+			return true
+		}
+	}
+	return false
+}
+
+// Only schedules with a fixed hour skip the repeated time, as in Vixie cron,
+// `*/15 * * * *` keeps firing every 15 minutes while clocks go back.
+func (s *Schedule) skipsOverlap(m matcher, t time.Time) bool {
+	if s.DST.Overlap != OverlapOnce || m.hourAny {
+		return false
+	}
+	_, repeated := firstOccurrence(t)
+	return repeated
+}
+
+// matcher holds the schedule fields as sets, missing fields match everything
+// except seconds which default to 0.
+type matcher struct {
+	second, minute, hour, dom, month, dow bits
+	domSpecials, dowSpecials              []Special
+	domAny, dowAny                        bool
+	// The hour field starts with `*`
+	hourAny bool
+	dayRule DayRule
+	// Sorted, empty means any year
+	years []int
+	// Resolution of fire times, a second with the seconds field, a minute otherwise
+	step time.Duration
+}
+
+func (s *Schedule) matcher() matcher {
+	sets := map[string]bits{}
+	full := map[string]bits{}
+	for _, slot := range parser.SecondsSlots {
+		full[slot.Label] = span(slot.Min, slot.Max)
+		sets[slot.Label] = full[slot.Label]
+	}
+	sets[Second] = newBits([]int{0})
+
+	step := time.Minute
+	var years []int
+	specials := map[string][]Special{}
+	// Missing fields are unrestricted
+	stars := map[string]bool{Hour: true, DayOfMonth: true, DayOfWeek: true}
+	for i := range s.Fields {
+		field := s.Fields[i]
+		if _, ok := sets[field.Label]; ok {
+			sets[field.Label] = newBits(field.Items)
+			specials[field.Label] = field.Specials
+			stars[field.Label] = star(field, full[field.Label])
+		}
+		if field.Label == Second {
+			step = time.Second
+		}
+		if field.Label == Year {
+			years = field.Items
+		}
+	}
+
+	return matcher{
+		second:      sets[Second],
+		minute:      sets[Minute],
+		hour:        sets[Hour],
+		dom:         sets[DayOfMonth],
+		month:       sets[Month],
+		dow:         sets[DayOfWeek],
+		domSpecials: specials[DayOfMonth],
+		dowSpecials: specials[DayOfWeek],
+		hourAny:     stars[Hour],
+		domAny:      stars[DayOfMonth],
+		dowAny:      stars[DayOfWeek],
+		dayRule:     s.DayRule,
+		years:       years,
+		step:        step,
+	}
+}
+
+// star reports whether a field starts with `*` or `?`, as Vixie cron decides it, e.g. `*/2` does but `1-31` does not.
+// Fields without units, e.g. built by hand, are unrestricted when they cover their whole range.
+func star(field Field, full bits) bool {
+	if len(field.Units) > 0 {
+		return field.Units[0].Any
+	}
+	return newBits(field.Items) == full && len(field.Specials) == 0
+}
+
+func (m matcher) matches(t time.Time) bool {
+	return m.hasYear(t.Year()) && m.month.has(int(t.Month())) && m.day(t) && m.hour.has(t.Hour()) && m.minute.has(t.Minute()) && m.second.has(t.Second())
+}
+
+func (m matcher) hasYear(year int) bool {
+	if len(m.years) == 0 {
+		return true
+	}
+	i := sort.SearchInts(m.years, year)
+	return i < len(m.years) && m.years[i] == year
+}
+
+func (m matcher) nextYear(year int) (int, bool) {
+	i := sort.SearchInts(m.years, year+1)
+	if i == len(m.years) {
+		return 0, false
+	}
+	return m.years[i], true
+}
+
+func (m matcher) prevYear(year int) (int, bool) {
+	i := sort.SearchInts(m.years, year)
+	if i == 0 {
+		return 0, false
+	}
+	return m.years[i-1], true
+}
+
+// Combined day of month and day of week rule, Vixie cron by default.
+func (m matcher) day(t time.Time) bool {
+	dom := m.dom.has(t.Day()) || anySpecial(m.domSpecials, t)
+	dow := m.dow.has(int(t.Weekday())) || anySpecial(m.dowSpecials, t)
+	if m.dayRule == DayRuleAnd || m.domAny || m.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// bits is a set of small non negative numbers, enough for every time field.
+type bits uint64
+
+func newBits(items []int) (b bits) {
+	for _, item := range items {
+		if item >= 0 && item < 64 {
+			b |= 1 << uint(item)
+		}
+	}
+	return b
+}
+
+func span(min, max int) (b bits) {
+	for k := min; k <= max; k++ {
+		b |= 1 << uint(k)
+	}
+	return b
+}
+
+func (b bits) has(v int) bool {
+	return v >= 0 && v < 64 && b&(1<<uint(v)) != 0
+}
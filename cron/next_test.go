@@ -0,0 +1,558 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	testCases := map[string]struct {
+		expression string
+		from       string
+		expected   string
+	}{
+		"Every minute": {
+			expression: `* * * * * cmd`,
+			from:       "2021-01-01T10:00:30Z",
+			expected:   "2021-01-01T10:01:00Z",
+		},
+		"Exact time is excluded": {
+			expression: `*/15 * * * * cmd`,
+			from:       "2021-01-01T10:15:00Z",
+			expected:   "2021-01-01T10:30:00Z",
+		},
+		"Next hour": {
+			expression: `*/15 0 * * * cmd`,
+			from:       "2021-01-01T00:50:00Z",
+			expected:   "2021-01-02T00:00:00Z",
+		},
+		"Next year": {
+			expression: `0 0 1 1 * cmd`,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "2022-01-01T00:00:00Z",
+		},
+		"Day of month or day of week": {
+			// Friday 2021-01-08, the 15th is the next day of month but Monday comes first
+			expression: `0 0 1,15 * 1 cmd`,
+			from:       "2021-01-08T12:00:00Z",
+			expected:   "2021-01-11T00:00:00Z",
+		},
+		"Day of month range is restricted": {
+			// Thursday 2024-01-04, `1-31` does not start with `*` so either day field matches
+			expression: `0 0 1-31 * 1 cmd`,
+			from:       "2024-01-04T12:00:00Z",
+			expected:   "2024-01-05T00:00:00Z",
+		},
+		"Day of month step is unrestricted": {
+			// `*/2` starts with `*`, so odd days have to be Mondays as well, the first one is 2024-01-01
+			expression: `0 0 */2 * 1 cmd`,
+			from:       "2024-01-01T12:00:00Z",
+			expected:   "2024-01-15T00:00:00Z",
+		},
+		"Day of week only": {
+			expression: `0 0 * * 1-5 cmd`,
+			from:       "2021-01-08T12:00:00Z",
+			expected:   "2021-01-11T00:00:00Z",
+		},
+		"Day of month only": {
+			expression: `0 0 31 * * cmd`,
+			from:       "2021-01-31T12:00:00Z",
+			expected:   "2021-03-31T00:00:00Z",
+		},
+		"Leap year": {
+			expression: `0 0 29 2 * cmd`,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "2024-02-29T00:00:00Z",
+		},
+		"Never": {
+			expression: `0 0 30 2 * cmd`,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "",
+		},
+		"Last day of month": {
+			expression: `0 0 L * * cmd`,
+			from:       "2021-02-01T00:00:00Z",
+			expected:   "2021-02-28T00:00:00Z",
+		},
+		"Days before last day of month": {
+			expression: `0 0 L-3 * * cmd`,
+			from:       "2021-02-01T00:00:00Z",
+			expected:   "2021-02-25T00:00:00Z",
+		},
+		"Last friday": {
+			expression: `0 0 * * 5L cmd`,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "2021-01-29T00:00:00Z",
+		},
+		"Last day or monday": {
+			expression: `0 0 L * 1 cmd`,
+			from:       "2021-01-26T00:00:00Z",
+			expected:   "2021-01-31T00:00:00Z",
+		},
+		"Nearest workday before saturday": {
+			expression: `0 0 15W * * cmd`,
+			from:       "2021-05-01T00:00:00Z",
+			expected:   "2021-05-14T00:00:00Z",
+		},
+		"Nearest workday after sunday": {
+			expression: `0 0 15W * * cmd`,
+			from:       "2021-08-01T00:00:00Z",
+			expected:   "2021-08-16T00:00:00Z",
+		},
+		"Nearest workday stays in month": {
+			expression: `0 0 1W * * cmd`,
+			from:       "2021-04-30T00:00:00Z",
+			expected:   "2021-05-03T00:00:00Z",
+		},
+		"Last workday": {
+			expression: `0 0 LW * * cmd`,
+			from:       "2021-10-01T00:00:00Z",
+			expected:   "2021-10-29T00:00:00Z",
+		},
+		"Second monday": {
+			expression: `0 0 * * MON#2 cmd`,
+			from:       "2021-03-01T00:00:00Z",
+			expected:   "2021-03-08T00:00:00Z",
+		},
+		"Fifth friday skips months without one": {
+			expression: `0 0 * * 5#5 cmd`,
+			from:       "2021-02-01T00:00:00Z",
+			expected:   "2021-04-30T00:00:00Z",
+		},
+		"Macro": {
+			expression: `@weekly cmd`,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "2021-01-03T00:00:00Z",
+		},
+		"Reboot": {
+			expression: `@reboot cmd`,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "",
+		},
+		"Keeps location": {
+			expression: `0 9 * * * cmd`,
+			from:       "2021-01-01T10:00:00+01:00",
+			expected:   "2021-01-02T09:00:00+01:00",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression)
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			got := schedule.Next(mustTime(t, testCase.from))
+			checkTime(t, testCase.expected, got)
+		})
+	}
+}
+
+func TestPrev(t *testing.T) {
+	testCases := map[string]struct {
+		expression string
+		from       string
+		expected   string
+	}{
+		"Every minute": {
+			expression: `* * * * * cmd`,
+			from:       "2021-01-01T10:00:30Z",
+			expected:   "2021-01-01T10:00:00Z",
+		},
+		"Exact time is excluded": {
+			expression: `*/15 * * * * cmd`,
+			from:       "2021-01-01T10:15:00Z",
+			expected:   "2021-01-01T10:00:00Z",
+		},
+		"Previous day": {
+			expression: `30 22 * * * cmd`,
+			from:       "2021-01-01T10:00:00Z",
+			expected:   "2020-12-31T22:30:00Z",
+		},
+		"Day of month or day of week": {
+			expression: `0 0 1,15 * 1 cmd`,
+			from:       "2021-01-16T12:00:00Z",
+			expected:   "2021-01-15T00:00:00Z",
+		},
+		"Day of month only": {
+			expression: `59 23 31 * * cmd`,
+			from:       "2021-03-01T00:00:00Z",
+			expected:   "2021-01-31T23:59:00Z",
+		},
+		"Never": {
+			expression: `0 0 30 2 * cmd`,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression)
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			got := schedule.Prev(mustTime(t, testCase.from))
+			checkTime(t, testCase.expected, got)
+		})
+	}
+}
+
+func TestSeconds(t *testing.T) {
+	testCases := map[string]struct {
+		expression string
+		backward   bool
+		from       string
+		expected   string
+	}{
+		"Next second": {
+			expression: `*/20 * * * * * cmd`,
+			from:       "2021-01-01T10:00:00Z",
+			expected:   "2021-01-01T10:00:20Z",
+		},
+		"Next minute": {
+			expression: `10 */5 * * * * cmd`,
+			from:       "2021-01-01T10:00:10Z",
+			expected:   "2021-01-01T10:05:10Z",
+		},
+		"Next day": {
+			expression: `30 0 0 * * * cmd`,
+			from:       "2021-01-01T00:00:30Z",
+			expected:   "2021-01-02T00:00:30Z",
+		},
+		"Prev second": {
+			expression: `*/20 * * * * * cmd`,
+			backward:   true,
+			from:       "2021-01-01T10:00:00Z",
+			expected:   "2021-01-01T09:59:40Z",
+		},
+		"Prev minute": {
+			expression: `10 */5 * * * * cmd`,
+			backward:   true,
+			from:       "2021-01-01T10:00:05Z",
+			expected:   "2021-01-01T09:55:10Z",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression, WithSeconds())
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			var got time.Time
+			if testCase.backward {
+				got = schedule.Prev(mustTime(t, testCase.from))
+			} else {
+				got = schedule.Next(mustTime(t, testCase.from))
+			}
+			checkTime(t, testCase.expected, got)
+		})
+	}
+}
+
+func TestQuartz(t *testing.T) {
+	testCases := map[string]struct {
+		expression string
+		backward   bool
+		from       string
+		expected   string
+	}{
+		"Wednesday noon": {
+			expression: `0 0 12 ? * WED`,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "2021-01-06T12:00:00Z",
+		},
+		"Year range": {
+			expression: `0 0 12 1 1 ? 2025-2030`,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "2025-01-01T12:00:00Z",
+		},
+		"After year range": {
+			expression: `0 0 12 1 1 ? 2025-2030`,
+			from:       "2030-01-01T12:00:00Z",
+			expected:   "",
+		},
+		"Far year": {
+			expression: `0 0 0 1 1 ? 2099`,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "2099-01-01T00:00:00Z",
+		},
+		"Prev year range": {
+			expression: `0 0 12 1 1 ? 2015,2018`,
+			backward:   true,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "2018-01-01T12:00:00Z",
+		},
+		"Prev before year range": {
+			expression: `0 0 12 1 1 ? 2025`,
+			backward:   true,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "",
+		},
+		"Last friday": {
+			expression: `0 0 0 ? * 6L`,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "2021-01-29T00:00:00Z",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression, WithQuartz())
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			var got time.Time
+			if testCase.backward {
+				got = schedule.Prev(mustTime(t, testCase.from))
+			} else {
+				got = schedule.Next(mustTime(t, testCase.from))
+			}
+			checkTime(t, testCase.expected, got)
+		})
+	}
+}
+
+func TestDialect(t *testing.T) {
+	testCases := map[string]struct {
+		expression  string
+		dialect     string
+		key         string
+		from        string
+		expected    string
+		expectedErr string
+	}{
+		"Vixie either day": {
+			expression: `0 0 1 * 1 /usr/bin/find`,
+			dialect:    DialectVixie,
+			from:       "2020-12-31T12:00:00Z",
+			expected:   "2021-01-01T00:00:00Z",
+		},
+		"Jenkins both days": {
+			expression: `0 0 1 * 1`,
+			dialect:    DialectJenkins,
+			from:       "2020-12-31T12:00:00Z",
+			expected:   "2021-02-01T00:00:00Z",
+		},
+		"Jenkins Sunday as 7": {
+			expression: `0 0 * * 7`,
+			dialect:    DialectJenkins,
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "2021-01-03T00:00:00Z",
+		},
+		"Jenkins hashed": {
+			expression: `H/15 * * * *`,
+			dialect:    DialectJenkins,
+			key:        "nightly-build",
+			from:       "2021-01-01T00:00:00Z",
+			expected:   "2021-01-01T00:11:00Z",
+		},
+		"AWS": {
+			expression: `0 10 ? * MON-FRI 2021`,
+			dialect:    "AWS",
+			from:       "2021-01-01T12:00:00Z",
+			expected:   "2021-01-04T10:00:00Z",
+		},
+
+		// Errors
+
+		"Unknown dialect": {
+			expression:  `0 0 * * *`,
+			dialect:     "fcron",
+			expectedErr: "unknown dialect `fcron`",
+		},
+		"Jenkins without key": {
+			expression:  `H * * * *`,
+			dialect:     DialectJenkins,
+			expectedErr: "`H` requires a key in `minute`",
+		},
+		"POSIX step": {
+			expression:  `*/5 * * * * /usr/bin/find`,
+			dialect:     DialectPOSIX,
+			expectedErr: "`*/5` does not match expected pattern `^[\\d|\\*|\\-|,]+$` in `minute`",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := Parse(testCase.expression, WithDialect(testCase.dialect), WithKey(testCase.key))
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			checkTime(t, testCase.expected, schedule.Next(mustTime(t, testCase.from)))
+		})
+	}
+}
+
+func TestDaylightSaving(t *testing.T) {
+	testCases := map[string]struct {
+		expression string
+		zone       string
+		policy     DSTPolicy
+		backward   bool
+		from       string
+		expected   string
+	}{
+		"Gap run after": {
+			expression: `30 2 * * * cmd`,
+			zone:       "Europe/Prague",
+			from:       "2021-03-28T00:00:00+01:00",
+			expected:   "2021-03-28T03:00:00+02:00",
+		},
+		"Gap skip": {
+			expression: `30 2 * * * cmd`,
+			zone:       "Europe/Prague",
+			policy:     DSTPolicy{Gap: GapSkip},
+			from:       "2021-03-28T00:00:00+01:00",
+			expected:   "2021-03-29T02:30:00+02:00",
+		},
+		"Gap run after other zone": {
+			expression: `30 2 * * * cmd`,
+			zone:       "America/New_York",
+			from:       "2021-03-14T00:00:00-05:00",
+			expected:   "2021-03-14T03:00:00-04:00",
+		},
+		"Gap runs once when the next minute matches too": {
+			expression: `0 * * * * cmd`,
+			zone:       "America/New_York",
+			from:       "2021-03-14T01:30:00-05:00",
+			expected:   "2021-03-14T03:00:00-04:00",
+		},
+		"Overlap once": {
+			expression: `30 2 * * * cmd`,
+			zone:       "Europe/Prague",
+			from:       "2021-10-31T02:45:00+02:00",
+			expected:   "2021-11-01T02:30:00+01:00",
+		},
+		"Overlap once hourly": {
+			// Wildcard hours fire in the repeated hour too
+			expression: `30 * * * * cmd`,
+			zone:       "Europe/Prague",
+			from:       "2021-10-31T02:30:00+02:00",
+			expected:   "2021-10-31T02:30:00+01:00",
+		},
+		"Overlap once every 15 minutes": {
+			expression: `*/15 * * * * cmd`,
+			zone:       "America/New_York",
+			from:       "2021-11-07T01:45:00-04:00",
+			expected:   "2021-11-07T01:00:00-05:00",
+		},
+		"Overlap once hour range": {
+			expression: `30 1-3 * * * cmd`,
+			zone:       "Europe/Prague",
+			from:       "2021-10-31T02:30:00+02:00",
+			expected:   "2021-10-31T03:30:00+01:00",
+		},
+		"Overlap twice": {
+			expression: `30 2 * * * cmd`,
+			zone:       "Europe/Prague",
+			policy:     DSTPolicy{Overlap: OverlapTwice},
+			from:       "2021-10-31T02:45:00+02:00",
+			expected:   "2021-10-31T02:30:00+01:00",
+		},
+		"Converts to location": {
+			expression: `0 9 * * * cmd`,
+			zone:       "Asia/Tokyo",
+			from:       "2020-12-31T23:00:00Z",
+			expected:   "2021-01-01T09:00:00+09:00",
+		},
+		"Prev gap run after": {
+			expression: `30 2 * * * cmd`,
+			zone:       "Europe/Prague",
+			backward:   true,
+			from:       "2021-03-28T12:00:00+02:00",
+			expected:   "2021-03-28T03:00:00+02:00",
+		},
+		"Prev gap skip": {
+			expression: `30 2 * * * cmd`,
+			zone:       "Europe/Prague",
+			policy:     DSTPolicy{Gap: GapSkip},
+			backward:   true,
+			from:       "2021-03-28T12:00:00+02:00",
+			expected:   "2021-03-27T02:30:00+01:00",
+		},
+		"Prev overlap once": {
+			expression: `30 2 * * * cmd`,
+			zone:       "Europe/Prague",
+			backward:   true,
+			from:       "2021-10-31T12:00:00+01:00",
+			expected:   "2021-10-31T02:30:00+02:00",
+		},
+		"Prev overlap twice": {
+			expression: `30 2 * * * cmd`,
+			zone:       "Europe/Prague",
+			policy:     DSTPolicy{Overlap: OverlapTwice},
+			backward:   true,
+			from:       "2021-10-31T12:00:00+01:00",
+			expected:   "2021-10-31T02:30:00+01:00",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			loc, err := time.LoadLocation(testCase.zone)
+			if err != nil {
+				t.Skipf("time zone database not available: %v", err)
+			}
+
+			schedule, err := Parse(testCase.expression, WithLocation(loc), WithDSTPolicy(testCase.policy))
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			var got time.Time
+			if testCase.backward {
+				got = schedule.Prev(mustTime(t, testCase.from))
+			} else {
+				got = schedule.Next(mustTime(t, testCase.from))
+			}
+			checkTime(t, testCase.expected, got)
+		})
+	}
+}
+
+func mustTime(t *testing.T, s string) time.Time {
+	tm, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatalf("invalid time %v: %v", s, err)
+	}
+	return tm
+}
+
+func checkTime(t *testing.T, expected string, got time.Time) {
+	if expected == "" {
+		if !got.IsZero() {
+			t.Errorf("expected zero time\nbut got: %v", got)
+		}
+		return
+	}
+
+	if got.Format(time.RFC3339) != expected {
+		t.Errorf("expected: %v\nbut got: %v", expected, got.Format(time.RFC3339))
+	}
+}
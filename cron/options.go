@@ -0,0 +1,178 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/gondo/cron-parser/internal/parser"
+)
+
+// Option configures Parse.
+type Option func(*settings)
+
+type settings struct {
+	dialect  Dialect
+	location *time.Location
+	dst      DSTPolicy
+	sources  parser.Sources
+	jitter   Jitter
+	system   bool
+	// Report every invalid field, see WithAllErrors
+	allErrors bool
+	// Names added to slots by label, see WithNames
+	names map[string]Names
+	err   error
+}
+
+func newSettings(options []Option) settings {
+	s := settings{dialect: parser.Vixie}
+	for _, option := range options {
+		option(&s)
+	}
+	return s
+}
+
+// WithLocation sets the location in which fire times are computed.
+// A `CRON_TZ=` or `TZ=` prefix of the expression takes precedence.
+func WithLocation(loc *time.Location) Option {
+	return func(s *settings) {
+		s.location = loc
+	}
+}
+
+// WithDSTPolicy sets how daylight saving changes are handled.
+func WithDSTPolicy(policy DSTPolicy) Option {
+	return func(s *settings) {
+		s.dst = policy
+	}
+}
+
+// WithJitter delays every fire time by an offset below max derived from key, e.g. a host name.
+// The offset is stable, so runs of one job stay evenly spaced while jobs with other keys spread over the window,
+// e.g. `0 * * * *` fires at 10:04:17, 11:04:17 ... for one key. See Jitter.
+func WithJitter(max time.Duration, key string) Option {
+	return func(s *settings) {
+		if max < 0 {
+			s.err = errors.New(fmt.Sprintf("negative jitter `%s`", max))
+			return
+		}
+		s.jitter = Jitter{Max: max, Key: key}
+	}
+}
+
+// WithDialect parses expressions of a registered dialect, e.g. cron.DialectPOSIX.
+// Parse fails for unknown names.
+func WithDialect(name string) Option {
+	return func(s *settings) {
+		dialect, ok := LookupDialect(name)
+		if !ok {
+			s.err = errors.New(fmt.Sprintf("unknown dialect `%s`", name))
+			return
+		}
+		s.dialect = dialect
+	}
+}
+
+// WithSeconds expects a seconds field before the standard five, as Spring and robfig/cron do,
+// e.g. `30 */15 * * * * /usr/bin/find`.
+func WithSeconds() Option {
+	return WithDialect(DialectSeconds)
+}
+
+// WithQuartz parses Quartz scheduler expressions: seconds first, an optional year last and no command,
+// e.g. `0 0 12 ? * WED 2025-2030`. Exactly one of day of month and day of week has to be `?`.
+// Day of week is numbered 1-7 from Sunday, Field items use the standard 0-6 numbering.
+func WithQuartz() Option {
+	return WithDialect(DialectQuartz)
+}
+
+// WithKey sets the key Jenkins `H` hashes, e.g. a job name. The same key always gives the same values,
+// different keys spread their runs over the allowed range, e.g. `H/15 * * * *` fires at 11, 26, 41, 56 for `nightly-build`.
+func WithKey(key string) Option {
+	return func(s *settings) {
+		s.sources.Key = key
+	}
+}
+
+// WithRand sets the source OpenBSD `~` values are picked from, e.g. a seeded one for repeatable results.
+// By default the package source of math/rand is used.
+func WithRand(r *rand.Rand) Option {
+	return func(s *settings) {
+		s.sources.Rand = r
+	}
+}
+
+// WithRandomRanges keeps the whole range of OpenBSD `~` units in Field items instead of picking a value,
+// e.g. for linting. Field Random still lists the units.
+func WithRandomRanges() Option {
+	return func(s *settings) {
+		s.sources.RandomRanges = true
+	}
+}
+
+// WithSystem parses system crontab lines, as in /etc/crontab and /etc/cron.d, which have a user name
+// between the fields and the command, e.g. `0 4 * * * root /usr/bin/find`. It requires a dialect with a command.
+func WithSystem() Option {
+	return func(s *settings) {
+		s.system = true
+	}
+}
+
+// WithNames accepts more names in the field with the given label, e.g. month names of another language.
+// Values use the standard numbering of Field items, Sunday = 0, and names are matched regardless of case.
+// Only fields that accept names in the dialect take them, e.g. not those of POSIX.
+func WithNames(label string, names Names) Option {
+	return func(s *settings) {
+		if s.names == nil {
+			s.names = map[string]Names{}
+		}
+		if s.names[label] == nil {
+			s.names[label] = Names{}
+		}
+		for name, value := range names {
+			s.names[label][name] = value
+		}
+	}
+}
+
+// WithLocale accepts the month and weekday names of a locale, e.g. `0 9 * März Montag` in German.
+func WithLocale(locale Locale) Option {
+	months, weekdays := Names{}, Names{}
+	for m := time.January; m <= time.December; m++ {
+		months[locale.Month(m)] = int(m)
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		weekdays[locale.Weekday(d)] = int(d)
+	}
+
+	return func(s *settings) {
+		WithNames(Month, months)(s)
+		WithNames(DayOfWeek, weekdays)(s)
+	}
+}
+
+// named returns the dialect with names added by WithNames.
+func (s settings) named(dialect Dialect) Dialect {
+	if len(s.names) == 0 {
+		return dialect
+	}
+
+	slots := make([]Slot, len(dialect.Slots))
+	for i, slot := range dialect.Slots {
+		slots[i] = slot.WithNames(s.names[slot.Label])
+	}
+	dialect.Slots = slots
+	return dialect
+}
+
+// WithAllErrors reports every invalid field of an expression as ParseErrors rather than the first one,
+// e.g. for editors and audits. Parse then returns a schedule of the valid fields along with the errors.
+// Fields that failed are missing from it and the schedule is Incomplete, so it never fires.
+// When the number of fields, a macro or the user are invalid there are no fields to return.
+func WithAllErrors() Option {
+	return func(s *settings) {
+		s.allErrors = true
+	}
+}
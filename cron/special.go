@@ -0,0 +1,57 @@
+package cron
+
+import "time"
+
+func anySpecial(specials []Special, t time.Time) bool {
+	for _, special := range specials {
+		if matchesSpecial(special, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesSpecial resolves a special day for the month of t.
+func matchesSpecial(special Special, t time.Time) bool {
+	last := daysIn(t.Year(), t.Month())
+
+	switch special.Kind {
+	case LastDay:
+		return t.Day() == last-special.Offset
+	case LastWeekday:
+		return int(t.Weekday()) == special.Value && t.Day()+7 > last
+	case NearestWorkday:
+		return t.Day() == nearestWorkday(t.Year(), t.Month(), special.Value, last)
+	case LastWorkday:
+		return t.Day() == nearestWorkday(t.Year(), t.Month(), last, last)
+	case NthWeekday:
+		return int(t.Weekday()) == special.Value && (t.Day()-1)/7+1 == special.Nth
+	}
+	return false
+}
+
+// nearestWorkday moves a weekend day to the closest Monday to Friday within the month.
+// Zero is returned for a day the month does not have.
+func nearestWorkday(year int, month time.Month, day, last int) int {
+	if day > last {
+		return 0
+	}
+
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
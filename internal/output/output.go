@@ -2,21 +2,33 @@ package output
 
 import (
 	"fmt"
-	"github.com/gondo/cron-parser/internal/parser"
+	"github.com/gondo/cron-parser/cron"
 	"strings"
 )
 
-func Table(results []parser.Result) string {
+func Table(fields []cron.Field) string {
 	var rows []string
 
-	for i := range results {
-		res := results[i]
-		rows = append(rows, Row(res.Label, SliceToStr(res.Items, " ")))
+	for i := range fields {
+		field := fields[i]
+		rows = append(rows, Row(field.Label, fieldToStr(field)))
 	}
 
 	return strings.Join(rows, "\n")
 }
 
+// Expanded items followed by special days such as `L`
+func fieldToStr(field cron.Field) string {
+	var parts []string
+	if len(field.Items) > 0 {
+		parts = append(parts, SliceToStr(field.Items, " "))
+	}
+	for i := range field.Specials {
+		parts = append(parts, field.Specials[i].String())
+	}
+	return strings.Join(parts, " ")
+}
+
 func SliceToStr(slice []int, sep string) string {
 	return strings.Trim(strings.Join(strings.Fields(fmt.Sprint(slice)), sep), "[]")
 }
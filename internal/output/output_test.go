@@ -1,17 +1,17 @@
 package output
 
 import (
-	"github.com/gondo/cron-parser/internal/parser"
+	"github.com/gondo/cron-parser/cron"
 	"testing"
 )
 
 func TestTable(t *testing.T) {
 	testCases := map[string]struct {
-		results  []parser.Result
+		fields   []cron.Field
 		expected string
 	}{
 		"Normal": {
-			results: []parser.Result{
+			fields: []cron.Field{
 				{
 					Label: "minute",
 					Items: []int{0, 15, 30, 45},
@@ -39,11 +39,26 @@ day of month  1 15
 month         1 2 3 4 5 6 7 8 9 10 11 12
 day of week   1 2 3 4 5`,
 		},
+		"Specials": {
+			fields: []cron.Field{
+				{
+					Label:    "day of month",
+					Items:    []int{1},
+					Specials: []cron.Special{{Kind: cron.LastDay, Offset: 2}},
+				},
+				{
+					Label:    "day of week",
+					Specials: []cron.Special{{Kind: cron.LastWeekday, Value: 5}},
+				},
+			},
+			expected: `day of month  1 L-2
+day of week   5L`,
+		},
 	}
 
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
-			tab := Table(testCase.results)
+			tab := Table(testCase.fields)
 
 			if tab != testCase.expected {
 				t.Errorf("expected: %v\nbut got: %v", testCase.expected, tab)
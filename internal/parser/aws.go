@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Units of `rate(value unit)`, singular for a value of 1, plural otherwise
+var rateUnits = map[string]time.Duration{
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+}
+
+// unwrap returns what is inside `name(...)` and its offset in input.
+func unwrap(input string, name string) (string, int, bool) {
+	if !strings.HasPrefix(input, name+"(") || !strings.HasSuffix(input, ")") {
+		return "", 0, false
+	}
+	inside := input[len(name)+1 : len(input)-1]
+	trimmed := cleanInput(inside)
+	return trimmed, len(name) + 1 + strings.Index(inside, trimmed), true
+}
+
+// EventBridge `rate(5 minutes)`, the interval counted as `@every` is.
+func parseRate(rate string, dialect Dialect) (Expression, error) {
+	parts := splitFields(rate, -1)
+	if len(parts) != 2 {
+		return Expression{}, newError(CodeRate, rate, fmt.Sprintf("invalid rate `%s`", rate))
+	}
+
+	value, err := strconv.Atoi(parts[0])
+	if nil != err || value < 1 {
+		return Expression{}, newError(CodeRate, parts[0], fmt.Sprintf("invalid rate value `%s`", parts[0]))
+	}
+
+	name := parts[1]
+	if value != 1 {
+		name = strings.TrimSuffix(name, "s")
+		if name == parts[1] {
+			return Expression{}, newError(CodeRate, parts[1], fmt.Sprintf("invalid rate unit `%s`", parts[1]))
+		}
+	}
+	unit, ok := rateUnits[name]
+	if !ok {
+		return Expression{}, newError(CodeRate, parts[1], fmt.Sprintf("invalid rate unit `%s`", parts[1]))
+	}
+
+	return Expression{Every: time.Duration(value) * unit, DayRule: dialect.DayRule}, nil
+}
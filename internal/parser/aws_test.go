@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRate(t *testing.T) {
+	testCases := map[string]struct {
+		input         string
+		expectedEvery time.Duration
+		expectedErr   string
+	}{
+		"Minutes": {
+			input:         `rate(5 minutes)`,
+			expectedEvery: 5 * time.Minute,
+		},
+		"Hour": {
+			input:         `rate(1 hour)`,
+			expectedEvery: time.Hour,
+		},
+		"Days": {
+			input:         ` rate(2 days) `,
+			expectedEvery: 48 * time.Hour,
+		},
+
+		// Errors
+
+		"Plural for one": {
+			input:       `rate(1 hours)`,
+			expectedErr: "invalid rate unit `hours`",
+		},
+		"Singular for more": {
+			input:       `rate(5 minute)`,
+			expectedErr: "invalid rate unit `minute`",
+		},
+		"Unknown unit": {
+			input:       `rate(5 weeks)`,
+			expectedErr: "invalid rate unit `weeks`",
+		},
+		"Zero": {
+			input:       `rate(0 minutes)`,
+			expectedErr: "invalid rate value `0`",
+		},
+		"Missing unit": {
+			input:       `rate(5)`,
+			expectedErr: "invalid rate `5`",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expression, err := ParseDialect(testCase.input, AWS)
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if expression.Every != testCase.expectedEvery {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expectedEvery, expression.Every)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package parser
+
+import "strings"
+
+// SplitCommand interprets `%` in a command as crontab(5) describes: the command ends at the first `%`
+// not escaped by a backslash, the rest is its standard input with every further `%` turned into a newline.
+// Escaped `\%` stands for a plain `%` in both parts, other backslashes are kept.
+func SplitCommand(command string) (run string, stdin string) {
+	var parts [2]strings.Builder
+	part := 0
+	for i := 0; i < len(command); i++ {
+		switch {
+		case command[i] == '\\' && i+1 < len(command) && command[i+1] == '%':
+			parts[part].WriteByte('%')
+			i++
+		case command[i] == '%' && part == 0:
+			part = 1
+		case command[i] == '%':
+			parts[part].WriteByte('\n')
+		default:
+			parts[part].WriteByte(command[i])
+		}
+	}
+	return parts[0].String(), parts[1].String()
+}
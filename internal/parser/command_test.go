@@ -0,0 +1,48 @@
+package parser
+
+import "testing"
+
+func TestSplitCommand(t *testing.T) {
+	testCases := map[string]struct {
+		input         string
+		expectedRun   string
+		expectedStdin string
+	}{
+		"Plain": {
+			input:       `/usr/bin/find -x`,
+			expectedRun: `/usr/bin/find -x`,
+		},
+		"Stdin": {
+			input:         `mail ops%Backup%done%`,
+			expectedRun:   `mail ops`,
+			expectedStdin: "Backup\ndone\n",
+		},
+		"Escaped": {
+			input:         `date +\%Y\%m%a \% b`,
+			expectedRun:   `date +%Y%m`,
+			expectedStdin: "a % b",
+		},
+		"Other backslashes": {
+			input:       `echo a\\b \n`,
+			expectedRun: `echo a\\b \n`,
+		},
+		"Empty stdin": {
+			input:       `cat%`,
+			expectedRun: `cat`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			run, stdin := SplitCommand(testCase.input)
+
+			if run != testCase.expectedRun {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expectedRun, run)
+			}
+
+			if stdin != testCase.expectedStdin {
+				t.Errorf("expected stdin: %q\nbut got: %q", testCase.expectedStdin, stdin)
+			}
+		})
+	}
+}
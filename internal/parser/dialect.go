@@ -0,0 +1,232 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DayRule tells how day of month and day of week combine.
+type DayRule int
+
+const (
+	// A day matching either of them fires, unless one is unrestricted, as in Vixie cron
+	DayRuleOr DayRule = iota
+	// A day has to match both
+	DayRuleAnd
+)
+
+// Dialect is a flavour of cron expressions. Allowed special characters, modifiers and
+// the numbering of values are set per slot.
+type Dialect struct {
+	Name  string
+	Slots []Slot
+	// Number of trailing slots that may be left out, only without a command
+	Optional int
+	// Expressions end with a command
+	Command bool
+	// A user name precedes the command, as in system crontabs such as /etc/crontab
+	User bool
+	// Nicknames of the standard five fields, nil when not supported
+	Macros map[string]string
+	// `@reboot` and `@every` are supported
+	Reboot bool
+	Every  bool
+	// Exactly one of day of month and day of week has to be `?`
+	QuestionMark bool
+	// Fields may be wrapped as `cron(...)` and intervals given as `rate(5 minutes)`, as in AWS EventBridge
+	Wrapped bool
+	DayRule DayRule
+}
+
+// Names of built-in dialects
+const (
+	DialectVixie   = "vixie"
+	DialectSeconds = "seconds"
+	DialectPOSIX   = "posix"
+	DialectQuartz  = "quartz"
+	DialectAWS     = "aws"
+	DialectJenkins = "jenkins"
+	DialectOpenBSD = "openbsd"
+)
+
+var Vixie = Dialect{
+	Name:    DialectVixie,
+	Slots:   Slots,
+	Command: true,
+	Macros:  Macros,
+	Reboot:  true,
+	Every:   true,
+	DayRule: DayRuleOr,
+}
+
+// Vixie with a seconds field first, as Spring and robfig/cron use
+var Seconds = Dialect{
+	Name:    DialectSeconds,
+	Slots:   SecondsSlots,
+	Command: true,
+	Macros:  Macros,
+	Reboot:  true,
+	Every:   true,
+	DayRule: DayRuleOr,
+}
+
+var POSIX = Dialect{
+	Name:    DialectPOSIX,
+	Slots:   POSIXSlots,
+	Command: true,
+	DayRule: DayRuleOr,
+}
+
+var Quartz = Dialect{
+	Name:         DialectQuartz,
+	Slots:        QuartzSlots,
+	Optional:     1,
+	QuestionMark: true,
+	DayRule:      DayRuleAnd,
+}
+
+var AWS = Dialect{
+	Name:         DialectAWS,
+	Slots:        AWSSlots,
+	QuestionMark: true,
+	Wrapped:      true,
+	DayRule:      DayRuleAnd,
+}
+
+var Jenkins = Dialect{
+	Name:    DialectJenkins,
+	Slots:   JenkinsSlots,
+	Macros:  JenkinsMacros,
+	DayRule: DayRuleAnd,
+}
+
+// Vixie with random values, OpenBSD has no `@every`
+var OpenBSD = Dialect{
+	Name:    DialectOpenBSD,
+	Slots:   OpenBSDSlots,
+	Command: true,
+	Macros:  Macros,
+	Reboot:  true,
+	DayRule: DayRuleOr,
+}
+
+var registry = struct {
+	sync.RWMutex
+	dialects map[string]Dialect
+}{
+	dialects: map[string]Dialect{},
+}
+
+func init() {
+	for _, dialect := range []Dialect{Vixie, Seconds, POSIX, Quartz, AWS, Jenkins, OpenBSD} {
+		registry.dialects[dialect.Name] = dialect
+	}
+}
+
+// RegisterDialect makes a dialect available by its name. Names are case insensitive
+// and can not be registered twice, built-in ones included.
+func RegisterDialect(dialect Dialect) error {
+	err := validateDialect(dialect)
+	if nil != err {
+		return err
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	name := strings.ToLower(dialect.Name)
+	if _, ok := registry.dialects[name]; ok {
+		return errors.New(fmt.Sprintf("dialect `%s` already registered", dialect.Name))
+	}
+	registry.dialects[name] = dialect.clone()
+	return nil
+}
+
+// LookupDialect returns a copy of a registered dialect, changing it does not change the registered one.
+func LookupDialect(name string) (Dialect, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	dialect, ok := registry.dialects[strings.ToLower(name)]
+	if !ok {
+		return Dialect{}, false
+	}
+	return dialect.clone(), true
+}
+
+// clone copies slots, their names and macros, which would be shared with the package tables otherwise.
+func (d Dialect) clone() Dialect {
+	slots := make([]Slot, len(d.Slots))
+	for i, slot := range d.Slots {
+		if slot.Names != nil {
+			slot.Names = slot.Names.clone()
+		}
+		slots[i] = slot
+	}
+	d.Slots = slots
+
+	if d.Macros != nil {
+		macros := map[string]string{}
+		for macro, fields := range d.Macros {
+			macros[macro] = fields
+		}
+		d.Macros = macros
+	}
+	return d
+}
+
+// Sorted names of registered dialects
+func Dialects() (names []string) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	for name := range registry.dialects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validateDialect(dialect Dialect) error {
+	if dialect.Name == "" {
+		return errors.New("dialect name missing")
+	}
+	if len(dialect.Slots) == 0 {
+		return errors.New(fmt.Sprintf("dialect `%s` has no slots", dialect.Name))
+	}
+	if dialect.Optional < 0 || dialect.Optional >= len(dialect.Slots) {
+		return errors.New(fmt.Sprintf("invalid number of optional slots in dialect `%s`", dialect.Name))
+	}
+	if dialect.Optional > 0 && dialect.Command {
+		return errors.New(fmt.Sprintf("dialect `%s` can not have both optional slots and a command", dialect.Name))
+	}
+	if dialect.User && !dialect.Command {
+		return errors.New(fmt.Sprintf("dialect `%s` can not have a user without a command", dialect.Name))
+	}
+	return nil
+}
+
+// Exactly one of day of month and day of week has to be `?`, the other one decides.
+func validateQuestionMark(sections []string, slots []Slot) (errs ParseErrors) {
+	count := 0
+	for i := range slots {
+		label := slots[i].Label
+		if label != LabelDayOfMonth && label != LabelDayOfWeek {
+			continue
+		}
+		if sections[i] == "?" {
+			count++
+		} else if strings.Contains(sections[i], "?") {
+			err := newError(CodeQuestionMark, "?", fmt.Sprintf("`?` can not be combined with other values in `%s`", label))
+			errs = append(errs, parseErrors(inField(err, i, slots[i]))...)
+		}
+	}
+
+	if len(errs) == 0 && count != 1 {
+		errs = append(errs, newError(CodeQuestionMark, "", fmt.Sprintf("`?` required in exactly one of `%s` and `%s`", LabelDayOfMonth, LabelDayOfWeek)))
+	}
+	return errs
+}
@@ -0,0 +1,365 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDialect(t *testing.T) {
+	testCases := map[string]struct {
+		input           string
+		dialect         Dialect
+		key             string
+		expectedResults []Result
+		expectedCommand string
+		expectedErr     string
+	}{
+		"Quartz with year": {
+			input:   `0 0 12 ? * WED 2025-2027`,
+			dialect: Quartz,
+			expectedResults: []Result{
+				{Label: "second", Items: []int{0}},
+				{Label: "minute", Items: []int{0}},
+				{Label: "hour", Items: []int{12}},
+				{Label: "day of month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}},
+				{Label: "month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+				{Label: "day of week", Items: []int{3}},
+				{Label: "year", Items: []int{2025, 2026, 2027}},
+			},
+		},
+		"Quartz without year": {
+			input:   `30 15 10 ? * 1-7,6L,2#3`,
+			dialect: Quartz,
+			expectedResults: []Result{
+				{Label: "second", Items: []int{30}},
+				{Label: "minute", Items: []int{15}},
+				{Label: "hour", Items: []int{10}},
+				{Label: "day of month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}},
+				{Label: "month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+				{
+					Label:    "day of week",
+					Items:    []int{0, 1, 2, 3, 4, 5, 6},
+					Specials: []Special{{Kind: LastWeekday, Value: 5}, {Kind: NthWeekday, Value: 1, Nth: 3}},
+				},
+			},
+		},
+		"Quartz day of month": {
+			input:   `0 0 0 LW * ?`,
+			dialect: Quartz,
+			expectedResults: []Result{
+				{Label: "second", Items: []int{0}},
+				{Label: "minute", Items: []int{0}},
+				{Label: "hour", Items: []int{0}},
+				{Label: "day of month", Specials: []Special{{Kind: LastWorkday}}},
+				{Label: "month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+				{Label: "day of week", Items: []int{0, 1, 2, 3, 4, 5, 6}},
+			},
+		},
+		"POSIX": {
+			input:   `0 12 1,15 * 1-5 /usr/bin/find`,
+			dialect: POSIX,
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{0}},
+				{Label: "hour", Items: []int{12}},
+				{Label: "day of month", Items: []int{1, 15}},
+				{Label: "month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+				{Label: "day of week", Items: []int{1, 2, 3, 4, 5}},
+			},
+			expectedCommand: `/usr/bin/find`,
+		},
+		"AWS": {
+			input:   `15 10 ? * 6L 2022-2023`,
+			dialect: AWS,
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{15}},
+				{Label: "hour", Items: []int{10}},
+				{Label: "day of month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}},
+				{Label: "month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+				{Label: "day of week", Specials: []Special{{Kind: LastWeekday, Value: 5}}},
+				{Label: "year", Items: []int{2022, 2023}},
+			},
+		},
+		"AWS wrapped": {
+			input:   `cron(0/30 8-9 ? * MON#1 2021)`,
+			dialect: AWS,
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{0, 30}},
+				{Label: "hour", Items: []int{8, 9}},
+				{Label: "day of month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}},
+				{Label: "month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+				{Label: "day of week", Specials: []Special{{Kind: NthWeekday, Value: 1, Nth: 1}}},
+				{Label: "year", Items: []int{2021}},
+			},
+		},
+		"Jenkins Sunday as 7": {
+			input:   `0 0 * * 5-7`,
+			dialect: Jenkins,
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{0}},
+				{Label: "hour", Items: []int{0}},
+				{Label: "day of month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}},
+				{Label: "month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+				{Label: "day of week", Items: []int{0, 5, 6}},
+			},
+		},
+		"Jenkins macro": {
+			input:   `@weekly`,
+			dialect: Jenkins,
+			key:     "nightly-build",
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{41}},
+				{Label: "hour", Items: []int{11}},
+				{Label: "day of month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}},
+				{Label: "month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+				{Label: "day of week", Items: []int{4}},
+			},
+		},
+
+		// Errors
+
+		"Short input": {
+			dialect:     Quartz,
+			input:       `0 0 12 ? *`,
+			expectedErr: "invalid number of sections",
+		},
+		"No question mark": {
+			dialect:     Quartz,
+			input:       `0 0 12 * * WED`,
+			expectedErr: "`?` required in exactly one of `day of month` and `day of week`",
+		},
+		"Two question marks": {
+			dialect:     Quartz,
+			input:       `0 0 12 ? * ?`,
+			expectedErr: "`?` required in exactly one of `day of month` and `day of week`",
+		},
+		"Combined question mark": {
+			dialect:     Quartz,
+			input:       `0 0 12 ?,1 * ?`,
+			expectedErr: "`?` can not be combined with other values in `day of month`",
+		},
+		"Day of week out of range": {
+			dialect:     Quartz,
+			input:       `0 0 12 ? * 0`,
+			expectedErr: "item `0` out of range in `day of week`",
+		},
+		"Year out of range": {
+			dialect:     Quartz,
+			input:       `0 0 12 ? * 1 2100`,
+			expectedErr: "item `2100` out of range in `year`",
+		},
+		"AWS without year": {
+			input:       `15 10 ? * 6L`,
+			dialect:     AWS,
+			expectedErr: "invalid number of sections",
+		},
+		"POSIX names": {
+			input:       `0 12 * * mon /usr/bin/find`,
+			dialect:     POSIX,
+			expectedErr: "`mon` does not match expected pattern `^[\\d|\\*|\\-|,]+$` in `day of week`",
+		},
+		"AWS wrapped command": {
+			input:       `cron(0 12 * * ? * /usr/bin/find)`,
+			dialect:     AWS,
+			expectedErr: "invalid number of sections",
+		},
+		"AWS unclosed": {
+			input:       `cron(0 12 * * ? *`,
+			dialect:     AWS,
+			expectedErr: "`cron(0` does not match expected pattern `^[\\d|\\*|\\-|,|/]+$` in `minute`",
+		},
+		"Vixie random": {
+			input:       `0~30 * * * * /usr/bin/find`,
+			dialect:     Vixie,
+			expectedErr: "`0~30` does not match expected pattern `^[\\d|\\*|\\-|,|/]+$` in `minute`",
+		},
+		"OpenBSD every": {
+			input:       `@every 1h /usr/bin/find`,
+			dialect:     OpenBSD,
+			expectedErr: "macro `@every` not supported",
+		},
+		"POSIX macro": {
+			input:       `@daily /usr/bin/find`,
+			dialect:     POSIX,
+			expectedErr: "macro `@daily` not supported",
+		},
+		"Jenkins reboot": {
+			input:       `@reboot`,
+			dialect:     Jenkins,
+			expectedErr: "macro `@reboot` not supported",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expression, err := ParseWith(testCase.input, testCase.dialect, Sources{Key: testCase.key})
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+					return
+				}
+
+				// Ignore other results
+				return
+			}
+
+			if testCase.expectedErr == "" && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if !reflect.DeepEqual(expression.Results, testCase.expectedResults) {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expectedResults, expression.Results)
+			}
+
+			if expression.Command != testCase.expectedCommand {
+				t.Errorf("expected command: %v\nbut got: %v", testCase.expectedCommand, expression.Command)
+			}
+		})
+	}
+}
+
+func TestRegisterDialect(t *testing.T) {
+	testCases := map[string]struct {
+		dialect     Dialect
+		expectedErr string
+	}{
+		"Custom": {
+			dialect: Dialect{Name: "Hourly", Slots: Slots[:1], DayRule: DayRuleOr},
+		},
+
+		// Errors
+
+		"Built-in": {
+			dialect:     Dialect{Name: "Vixie", Slots: Slots, Command: true},
+			expectedErr: "dialect `Vixie` already registered",
+		},
+		"No name": {
+			dialect:     Dialect{Slots: Slots},
+			expectedErr: "dialect name missing",
+		},
+		"No slots": {
+			dialect:     Dialect{Name: "empty"},
+			expectedErr: "dialect `empty` has no slots",
+		},
+		"User without command": {
+			dialect:     Dialect{Name: "nobody", Slots: Slots, User: true},
+			expectedErr: "dialect `nobody` can not have a user without a command",
+		},
+		"Optional with command": {
+			dialect:     Dialect{Name: "both", Slots: Slots, Optional: 1, Command: true},
+			expectedErr: "dialect `both` can not have both optional slots and a command",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := RegisterDialect(testCase.dialect)
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if _, ok := LookupDialect("hourly"); !ok {
+				t.Errorf("expected dialect `hourly` to be registered")
+			}
+		})
+	}
+}
+
+func TestParseUser(t *testing.T) {
+	testCases := map[string]struct {
+		input           string
+		expectedUser    string
+		expectedCommand string
+		expectedErr     string
+	}{
+		"Fields": {
+			input:           `*/15 0 1,15 * 1-5 root /usr/bin/find -x`,
+			expectedUser:    "root",
+			expectedCommand: `/usr/bin/find -x`,
+		},
+		"Macro": {
+			input:           `@daily www-data /usr/bin/find`,
+			expectedUser:    "www-data",
+			expectedCommand: `/usr/bin/find`,
+		},
+		"Every": {
+			input:           `@every 1h _backup /usr/bin/find`,
+			expectedUser:    "_backup",
+			expectedCommand: `/usr/bin/find`,
+		},
+		"Samba machine account": {
+			input:           `0 0 * * * host$ /usr/bin/find`,
+			expectedUser:    "host$",
+			expectedCommand: `/usr/bin/find`,
+		},
+
+		// Errors
+
+		"Missing command": {
+			input:       `0 0 * * * root`,
+			expectedErr: "invalid number of sections",
+		},
+		"Macro missing command": {
+			input:       `@reboot root`,
+			expectedErr: "invalid number of sections",
+		},
+		"Starts with digit": {
+			input:       `0 0 * * * 1000 /usr/bin/find`,
+			expectedErr: "invalid user `1000`",
+		},
+		"Too long": {
+			input:       `0 0 * * * abcdefghijklmnopqrstuvwxyz0123456 /usr/bin/find`,
+			expectedErr: "invalid user `abcdefghijklmnopqrstuvwxyz0123456`",
+		},
+	}
+
+	dialect := Vixie
+	dialect.User = true
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expression, err := ParseDialect(testCase.input, dialect)
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if expression.User != testCase.expectedUser || expression.Command != testCase.expectedCommand {
+				t.Errorf("expected user %v and command %v\nbut got user %v and command %v", testCase.expectedUser, testCase.expectedCommand, expression.User, expression.Command)
+			}
+		})
+	}
+}
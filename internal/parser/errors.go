@@ -0,0 +1,220 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorCode tells what is wrong with an expression. Codes are stable, messages may change.
+type ErrorCode string
+
+const (
+	CodeSections     ErrorCode = "sections"      // Wrong number of fields
+	CodeUser         ErrorCode = "user"          // Invalid user name
+	CodeMacro        ErrorCode = "macro"         // Unknown or unsupported macro
+	CodeDuration     ErrorCode = "duration"      // Invalid `@every` duration
+	CodeRate         ErrorCode = "rate"          // Invalid EventBridge rate
+	CodeCharacters   ErrorCode = "characters"    // Characters the field does not allow
+	CodeToken        ErrorCode = "token"         // Unrecognized name
+	CodeQuestionMark ErrorCode = "question_mark" // Misplaced `?`
+	CodeStep         ErrorCode = "step"          // Invalid step
+	CodeRangeStart   ErrorCode = "range_start"   // Invalid or too small start of a range
+	CodeRangeEnd     ErrorCode = "range_end"     // Invalid or too large end of a range
+	CodeRangeOrder   ErrorCode = "range_order"   // Range start after its end
+	CodeItem         ErrorCode = "item"          // Invalid value
+	CodeOutOfRange   ErrorCode = "out_of_range"  // Value outside the field range
+	CodeKey          ErrorCode = "key"           // `H` without a key
+	CodeTimeZone     ErrorCode = "time_zone"     // Unknown time zone
+)
+
+// ParseError is an invalid part of an expression, found with errors.As.
+type ParseError struct {
+	Code ErrorCode
+	// Index of the field, -1 when the error is not about one field
+	Field int
+	// Label of the field, empty when the error is not about one field
+	Label string
+	// Offending part of the input as written, the whole field or expression when no single part is at fault
+	Token string
+	// Byte offset of the token in the input
+	Offset  int
+	Message string
+	// Byte offset of the token within its field when known exactly, -1 otherwise
+	position int
+}
+
+func (e *ParseError) Error() string {
+	return e.Message
+}
+
+func newError(code ErrorCode, token string, message string) *ParseError {
+	return &ParseError{Code: code, Field: -1, Token: token, Offset: -1, Message: message, position: -1}
+}
+
+// within places the message of an error in a field, e.g. "`invalid step` in `minute`".
+func within(err error, slot Slot) error {
+	e, ok := err.(*ParseError)
+	if !ok {
+		return err
+	}
+	wrapped := *e
+	wrapped.Message = fmt.Sprintf("`%s` in `%s`", e.Message, slot.Label)
+	return &wrapped
+}
+
+// inField sets the field of an error.
+func inField(err error, index int, slot Slot) error {
+	if e, ok := err.(*ParseError); ok {
+		e.Field, e.Label = index, slot.Label
+	}
+	return err
+}
+
+// locate sets the offset of errors not located yet by finding their tokens in input, which starts at offset.
+// A token that is not found, e.g. a name already replaced with its value, becomes input as a whole.
+func locate(err error, input string, offset int) error {
+	for _, e := range parseErrors(err) {
+		if e.Offset >= 0 {
+			continue
+		}
+
+		position := e.position
+		if position < 0 {
+			position = indexFold(input, e.Token)
+		}
+		if e.Token == "" || position < 0 {
+			e.Token, position = input, 0
+		}
+		e.Offset = offset + position
+	}
+	return err
+}
+
+// written holds the comma separated units of a field as written and their byte offsets within it,
+// errors found in units normalized later are placed by the index of their unit.
+type written struct {
+	units   []string
+	offsets []int
+}
+
+func writtenUnits(section string) (w written) {
+	offset := 0
+	for _, unit := range strings.Split(section, ",") {
+		w.units = append(w.units, unit)
+		w.offsets = append(w.offsets, offset)
+		offset += len(unit) + 1
+	}
+	return w
+}
+
+// with adds unit j of other.
+func (w written) with(other written, j int) written {
+	if j < len(other.units) {
+		w.units = append(w.units, other.units[j])
+		w.offsets = append(w.offsets, other.offsets[j])
+	}
+	return w
+}
+
+// place places errors found in unit j not placed yet: a step after its `/`, a range end after its `-` or `~`,
+// a range start at its first value and anything else at the unit. Tokens become those parts as written.
+func (w written) place(err error, j int) error {
+	if j >= len(w.units) {
+		return err
+	}
+	unit, offset := w.units[j], w.offsets[j]
+
+	item, step := unit, ""
+	if i := strings.Index(unit, "/"); i >= 0 {
+		item, step = unit[:i], unit[i+1:]
+	}
+	// Bounds of a range, within `H(a-b)` too
+	bounds, start := item, 0
+	if strings.HasPrefix(bounds, "H(") && strings.HasSuffix(bounds, ")") {
+		bounds, start = bounds[2:len(bounds)-1], 2
+	}
+	end := len(bounds)
+	if i := strings.IndexAny(bounds, "-~"); i >= 0 {
+		end = i
+	}
+
+	for _, e := range parseErrors(err) {
+		if e.position >= 0 {
+			continue
+		}
+		switch {
+		case e.Code == CodeStep && step != "":
+			e.Token, e.position = step, offset+len(item)+1
+		case e.Code == CodeRangeStart:
+			e.Token, e.position = bounds[:end], offset+start
+		case e.Code == CodeRangeEnd && end < len(bounds):
+			e.Token, e.position = bounds[end+1:], offset+start+end+1
+		default:
+			e.Token, e.position = item, offset
+		}
+	}
+	return err
+}
+
+// locateField locates errors within their fields, or within input when they have none.
+func locateField(err error, input string, sections []string, offsets []int) error {
+	for _, e := range parseErrors(err) {
+		if e.Field >= 0 && e.Field < len(sections) {
+			locate(e, sections[e.Field], offsets[e.Field])
+		} else {
+			locate(e, input, 0)
+		}
+	}
+	return err
+}
+
+// shift moves the offsets of errors found in a part of the input starting at offset.
+func shift(err error, offset int) error {
+	for _, e := range parseErrors(err) {
+		if e.Offset >= 0 {
+			e.Offset += offset
+		}
+	}
+	return err
+}
+
+// ParseErrors lists every invalid part of an expression, see ParseAll.
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	var messages []string
+	for i := range e {
+		messages = append(messages, e[i].Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap lets errors.As find each of the errors.
+func (e ParseErrors) Unwrap() []error {
+	var errs []error
+	for i := range e {
+		errs = append(errs, e[i])
+	}
+	return errs
+}
+
+// parseErrors returns the parse errors err holds.
+func parseErrors(err error) []*ParseError {
+	switch e := err.(type) {
+	case *ParseError:
+		return []*ParseError{e}
+	case ParseErrors:
+		return e
+	}
+	return nil
+}
+
+// indexFold returns the first index of substr in s regardless of case, -1 when not present.
+func indexFold(s string, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,215 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	system := Vixie
+	system.User = true
+
+	testCases := map[string]struct {
+		input    string
+		dialect  Dialect
+		expected ParseError
+	}{
+		"Out of range": {
+			input:    `0 25 * * * /usr/bin/find`,
+			dialect:  Vixie,
+			expected: ParseError{Code: CodeOutOfRange, Field: 1, Label: LabelHour, Token: "25", Offset: 2},
+		},
+		"Leading blanks": {
+			input:    `  0 5-25 * * * /usr/bin/find`,
+			dialect:  Vixie,
+			expected: ParseError{Code: CodeRangeEnd, Field: 1, Label: LabelHour, Token: "25", Offset: 6},
+		},
+		"Step": {
+			input:    `*/0 * * * * /usr/bin/find`,
+			dialect:  Vixie,
+			expected: ParseError{Code: CodeStep, Field: 0, Label: LabelMinute, Token: "0", Offset: 2},
+		},
+		"Repeated token": {
+			input:    `0 0 10,0 * * /usr/bin/find`,
+			dialect:  Vixie,
+			expected: ParseError{Code: CodeOutOfRange, Field: 2, Label: LabelDayOfMonth, Token: "0", Offset: 7},
+		},
+		"Range end after a name": {
+			input:    `0 0 * jan-13 * /usr/bin/find`,
+			dialect:  Vixie,
+			expected: ParseError{Code: CodeRangeEnd, Field: 3, Label: LabelMonth, Token: "13", Offset: 10},
+		},
+		"Step after a star": {
+			input:    `0 0 * * 1,*/0 /usr/bin/find`,
+			dialect:  Vixie,
+			expected: ParseError{Code: CodeStep, Field: 4, Label: LabelDayOfWeek, Token: "0", Offset: 12},
+		},
+		"Unrecognized token": {
+			input:    `0 0 * * mon,mo /usr/bin/find`,
+			dialect:  Vixie,
+			expected: ParseError{Code: CodeToken, Field: 4, Label: LabelDayOfWeek, Token: "mo", Offset: 12},
+		},
+		"Names": {
+			input:    `0 0 * dec-jan * /usr/bin/find`,
+			dialect:  Vixie,
+			expected: ParseError{Code: CodeRangeOrder, Field: 3, Label: LabelMonth, Token: "dec-jan", Offset: 6},
+		},
+		"Characters": {
+			input:    `0 0 1W * * /usr/bin/find`,
+			dialect:  POSIX,
+			expected: ParseError{Code: CodeCharacters, Field: 2, Label: LabelDayOfMonth, Token: "1W", Offset: 4},
+		},
+		"Sections": {
+			input:    `0 0 * *`,
+			dialect:  Vixie,
+			expected: ParseError{Code: CodeSections, Field: -1, Token: "0 0 * *", Offset: 0},
+		},
+		"User": {
+			input:    `0 4 * * * 1root /usr/bin/find`,
+			dialect:  system,
+			expected: ParseError{Code: CodeUser, Field: -1, Token: "1root", Offset: 10},
+		},
+		"Macro": {
+			input:    `@often /usr/bin/find`,
+			dialect:  Vixie,
+			expected: ParseError{Code: CodeMacro, Field: -1, Token: "@often", Offset: 0},
+		},
+		"Duration": {
+			input:    `@every 90x /usr/bin/find`,
+			dialect:  Vixie,
+			expected: ParseError{Code: CodeDuration, Field: -1, Token: "90x", Offset: 7},
+		},
+		"Macro fields": {
+			input:    `@hourly`,
+			dialect:  Jenkins,
+			expected: ParseError{Code: CodeKey, Field: 0, Label: LabelMinute, Token: "@hourly", Offset: 0},
+		},
+		"Question mark": {
+			input:    `0 0 12 1? * WED`,
+			dialect:  Quartz,
+			expected: ParseError{Code: CodeQuestionMark, Field: 3, Label: LabelDayOfMonth, Token: "?", Offset: 8},
+		},
+		"Wrapped": {
+			input:    `cron(0 12 ? * MON#6 *)`,
+			dialect:  AWS,
+			expected: ParseError{Code: CodeOutOfRange, Field: 4, Label: LabelDayOfWeek, Token: "MON#6", Offset: 14},
+		},
+		"Rate": {
+			input:    `rate( 5 weeks)`,
+			dialect:  AWS,
+			expected: ParseError{Code: CodeRate, Field: -1, Token: "weeks", Offset: 8},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseWith(testCase.input, testCase.dialect, Sources{})
+
+			var got *ParseError
+			if !errors.As(err, &got) {
+				t.Errorf("expected a parse error, got: %v", err)
+				return
+			}
+
+			expected := testCase.expected
+			if got.Code != expected.Code || got.Field != expected.Field || got.Label != expected.Label || got.Token != expected.Token || got.Offset != expected.Offset {
+				t.Errorf("expected: %+v\nbut got: %+v", expected, *got)
+			}
+			if got.Token != testCase.input[got.Offset:got.Offset+len(got.Token)] {
+				t.Errorf("expected token `%s` at offset %d of `%s`", got.Token, got.Offset, testCase.input)
+			}
+		})
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	testCases := map[string]struct {
+		input           string
+		dialect         Dialect
+		expectedLabels  []string
+		expectedErrors  []ParseError
+		expectedMessage string
+	}{
+		"Valid": {
+			input:          `0 12 * * 1-5 /usr/bin/find`,
+			dialect:        Vixie,
+			expectedLabels: []string{LabelMinute, LabelHour, LabelDayOfMonth, LabelMonth, LabelDayOfWeek},
+		},
+		"Several fields": {
+			input:          `60 12 * 13 xyz /usr/bin/find`,
+			dialect:        Vixie,
+			expectedLabels: []string{LabelHour, LabelDayOfMonth},
+			expectedErrors: []ParseError{
+				{Code: CodeOutOfRange, Field: 0, Label: LabelMinute, Token: "60", Offset: 0},
+				{Code: CodeOutOfRange, Field: 3, Label: LabelMonth, Token: "13", Offset: 8},
+				{Code: CodeToken, Field: 4, Label: LabelDayOfWeek, Token: "xyz", Offset: 11},
+			},
+			expectedMessage: "item `60` out of range in `minute`\nitem `13` out of range in `month`\nunrecognized token `xyz` in `day of week`",
+		},
+		"Question mark": {
+			input:          `0 0 12 1? * 1-5 2100`,
+			dialect:        Quartz,
+			expectedLabels: []string{LabelSecond, LabelMinute, LabelHour, LabelMonth, LabelDayOfWeek},
+			expectedErrors: []ParseError{
+				{Code: CodeQuestionMark, Field: 3, Label: LabelDayOfMonth, Token: "?", Offset: 8},
+				{Code: CodeOutOfRange, Field: 6, Label: LabelYear, Token: "2100", Offset: 16},
+			},
+		},
+		"Macro": {
+			input:          `@daily`,
+			dialect:        Jenkins,
+			expectedLabels: []string{LabelDayOfMonth, LabelMonth, LabelDayOfWeek},
+			expectedErrors: []ParseError{
+				{Code: CodeKey, Field: 0, Label: LabelMinute, Token: "@daily", Offset: 0},
+				{Code: CodeKey, Field: 1, Label: LabelHour, Token: "@daily", Offset: 0},
+			},
+		},
+		"Sections": {
+			input:   `0 0 * *`,
+			dialect: Vixie,
+			expectedErrors: []ParseError{
+				{Code: CodeSections, Field: -1, Token: "0 0 * *", Offset: 0},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expression, err := ParseAll(testCase.input, testCase.dialect, Sources{})
+
+			var labels []string
+			for _, result := range expression.Results {
+				labels = append(labels, result.Label)
+			}
+			if !reflect.DeepEqual(labels, testCase.expectedLabels) {
+				t.Errorf("expected results: %v\nbut got: %v", testCase.expectedLabels, labels)
+			}
+			if len(expression.Units) != len(expression.Results) {
+				t.Errorf("expected units of %d results, got: %d", len(expression.Results), len(expression.Units))
+			}
+
+			if len(testCase.expectedErrors) == 0 {
+				if err != nil {
+					t.Errorf("expected no error, got: %v", err)
+				}
+				return
+			}
+
+			errs, ok := err.(ParseErrors)
+			if !ok || len(errs) != len(testCase.expectedErrors) {
+				t.Errorf("expected %d errors, got: %v", len(testCase.expectedErrors), err)
+				return
+			}
+			for i, expected := range testCase.expectedErrors {
+				got := errs[i]
+				if got.Code != expected.Code || got.Field != expected.Field || got.Label != expected.Label || got.Token != expected.Token || got.Offset != expected.Offset {
+					t.Errorf("expected: %+v\nbut got: %+v", expected, *got)
+				}
+			}
+			if testCase.expectedMessage != "" && err.Error() != testCase.expectedMessage {
+				t.Errorf("expected message: %v\nbut got: %v", testCase.expectedMessage, err)
+			}
+		})
+	}
+}
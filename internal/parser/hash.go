@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Sources holds what values of an expression may depend on besides its text.
+type Sources struct {
+	// Hashed by `H` so the same key always gets the same values, e.g. a job name
+	Key string
+	// Picks values of `~`, the package source of math/rand when nil
+	Rand *rand.Rand
+	// Keep the whole range of `~` units instead of picking a value
+	RandomRanges bool
+}
+
+// Replace Jenkins `H`, `H/n`, `H(a-b)` and `H(a-b)/n` units with values derived from the key.
+func resolveHash(section string, slot Slot, sources Sources, w written) (string, error) {
+	if slot.Modifiers&ModifierHash == 0 || !strings.Contains(section, "H") {
+		return section, nil
+	}
+	if sources.Key == "" {
+		return "", newError(CodeKey, "H", fmt.Sprintf("`H` requires a key in `%s`", slot.Label))
+	}
+
+	units := strings.Split(section, ",")
+	for j := range units {
+		if !strings.HasPrefix(units[j], "H") {
+			continue
+		}
+		unit, err := resolveHashUnit(units[j], slot, hash(sources.Key, slot))
+		if nil != err {
+			return "", w.place(err, j)
+		}
+		units[j] = unit
+	}
+	return strings.Join(units, ","), nil
+}
+
+func resolveHashUnit(unit string, slot Slot, hash int) (string, error) {
+	step, rest, err := parseStep(unit)
+	if nil != err {
+		return "", within(err, slot)
+	}
+
+	min, max := hashRange(slot)
+	end := slot.Max
+	if rest != "H" {
+		if !strings.HasPrefix(rest, "H(") || !strings.HasSuffix(rest, ")") || !isRange(rest) {
+			return "", newError(CodeItem, unit, fmt.Sprintf("invalid item `%s` in `%s`", unit, slot.Label))
+		}
+		items, err := parseRange(rest[2:len(rest)-1], slot, 0)
+		if nil != err {
+			return "", err
+		}
+		min, max = items[0], items[len(items)-1]
+		end = max
+	}
+
+	if step == 0 {
+		return strconv.Itoa(min + hash%(max-min+1)), nil
+	}
+	if step > end-min+1 {
+		step = end - min + 1
+	}
+	return fmt.Sprintf("%d-%d/%d", min+hash%step, end, step), nil
+}
+
+// Values plain `H` picks from. Day of month stops at 28 to fire in every month,
+// day of week leaves out the value that repeats Sunday.
+func hashRange(slot Slot) (int, int) {
+	switch {
+	case slot.Label == LabelDayOfMonth && slot.Max > 28:
+		return slot.Min, 28
+	case slot.Modulo > 0 && slot.Max >= slot.Modulo:
+		return slot.Min, slot.Modulo - 1
+	}
+	return slot.Min, slot.Max
+}
+
+// Fields are hashed separately, so `H H * * *` does not run at e.g. 5:05 for every key.
+func hash(key string, slot Slot) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(slot.Label))
+	return int(h.Sum32() & 0x7fffffff)
+}
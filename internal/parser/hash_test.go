@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveHash(t *testing.T) {
+	testCases := map[string]struct {
+		input           string
+		key             string
+		expectedResults []Result
+		expectedErr     string
+	}{
+		"Plain and ranges": {
+			input: `H/15 H(8-17) H H(1-6) H`,
+			key:   "nightly-build",
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{11, 26, 41, 56}},
+				{Label: "hour", Items: []int{17}},
+				{Label: "day of month", Items: []int{25}},
+				{Label: "month", Items: []int{6}},
+				{Label: "day of week", Items: []int{4}},
+			},
+		},
+		"Steps": {
+			input: `H(0-29)/10 H/6 H/10 * H(1-5)`,
+			key:   "nightly-build",
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{1, 11, 21}},
+				{Label: "hour", Items: []int{5, 11, 17, 23}},
+				{Label: "day of month", Items: []int{1, 11, 21, 31}},
+				{Label: "month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+				{Label: "day of week", Items: []int{3}},
+			},
+		},
+		"Other key": {
+			input: `H H 1 1 0`,
+			key:   "deploy",
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{38}},
+				{Label: "hour", Items: []int{8}},
+				{Label: "day of month", Items: []int{1}},
+				{Label: "month", Items: []int{1}},
+				{Label: "day of week", Items: []int{0}},
+			},
+		},
+
+		// Errors
+
+		"Missing key": {
+			input:       `H * * * *`,
+			expectedErr: "`H` requires a key in `minute`",
+		},
+		"Range out of slot": {
+			input:       `H(30-60) * * * *`,
+			key:         "deploy",
+			expectedErr: "invalid range end in `minute`",
+		},
+		"Unclosed range": {
+			input:       `H(0-29 * * * *`,
+			key:         "deploy",
+			expectedErr: "invalid item `H(0-29` in `minute`",
+		},
+		"Invalid step": {
+			input:       `H/0 * * * *`,
+			key:         "deploy",
+			expectedErr: "`invalid step` in `minute`",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expression, err := ParseWith(testCase.input, Jenkins, Sources{Key: testCase.key})
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if !reflect.DeepEqual(expression.Results, testCase.expectedResults) {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expectedResults, expression.Results)
+			}
+		})
+	}
+}
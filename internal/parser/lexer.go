@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota + 1 // `15`
+	tokenWord                        // Letters and digits with at least one letter, e.g. `jan`, `5L` or `1月`
+	tokenSymbol                      // One of the other characters, e.g. `-` or `/`
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// Byte offset within the section
+	offset int
+}
+
+// lex splits a section into numbers, words and single symbols.
+func lex(section string) (tokens []token) {
+	for i := 0; i < len(section); {
+		r, size := utf8.DecodeRuneInString(section[i:])
+		if !isWordRune(r) {
+			tokens = append(tokens, token{kind: tokenSymbol, text: section[i : i+size], offset: i})
+			i += size
+			continue
+		}
+
+		start, kind := i, tokenNumber
+		for i < len(section) {
+			r, size = utf8.DecodeRuneInString(section[i:])
+			if !isWordRune(r) {
+				break
+			}
+			if unicode.IsLetter(r) {
+				kind = tokenWord
+			}
+			i += size
+		}
+		tokens = append(tokens, token{kind: kind, text: section[start:i], offset: start})
+	}
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Words of special characters, upper cased, by the modifier allowing them
+var modifierWords = []struct {
+	modifier Modifier
+	pattern  *regexp.Regexp
+}{
+	{ModifierLast, regexp.MustCompile(`^\d*L$`)},
+	{ModifierLast | ModifierWeekday, regexp.MustCompile(`^LW$`)},
+	{ModifierWeekday, regexp.MustCompile(`^\d+W$`)},
+	{ModifierHash, regexp.MustCompile(`^H$`)},
+}
+
+// Replace names of values with their numbers, e.g. `mon-fri` with `1-5`. Names are whole words
+// standing for a value in a list, range or step start, words of modifiers such as `5L` are kept.
+func resolveNames(section string, slot Slot) (string, error) {
+	var resolved strings.Builder
+	var previous token
+	for _, t := range lex(section) {
+		text := t.text
+		if t.kind == tokenWord {
+			var err error
+			text, err = resolveWord(t, previous, slot)
+			if nil != err {
+				return "", err
+			}
+		}
+		resolved.WriteString(text)
+		previous = t
+	}
+	return resolved.String(), nil
+}
+
+func resolveWord(t token, previous token, slot Slot) (string, error) {
+	word := strings.ToUpper(t.text)
+	for _, w := range modifierWords {
+		if slot.Modifiers&w.modifier == w.modifier && w.pattern.MatchString(word) {
+			return t.text, nil
+		}
+	}
+
+	// Steps and occurrences are counts, not values
+	if previous.text != "/" && previous.text != "#" {
+		name := strings.ToLower(t.text)
+		if value, ok := slot.Names[name]; ok {
+			return strconv.Itoa(value), nil
+		}
+		// Last weekday of month, e.g. `friL`
+		if value, ok := slot.Names[strings.TrimSuffix(name, "l")]; ok && slot.Modifiers&ModifierLast != 0 && strings.HasSuffix(name, "l") {
+			return strconv.Itoa(value) + "L", nil
+		}
+	}
+
+	err := newError(CodeToken, t.text, fmt.Sprintf("unrecognized token `%s` in `%s`", t.text, slot.Label))
+	err.position = t.offset
+	return "", err
+}
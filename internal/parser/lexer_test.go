@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLex(t *testing.T) {
+	testCases := map[string]struct {
+		section  string
+		expected []token
+	}{
+		"Range with step": {
+			section: "mon-5/2",
+			expected: []token{
+				{kind: tokenWord, text: "mon", offset: 0},
+				{kind: tokenSymbol, text: "-", offset: 3},
+				{kind: tokenNumber, text: "5", offset: 4},
+				{kind: tokenSymbol, text: "/", offset: 5},
+				{kind: tokenNumber, text: "2", offset: 6},
+			},
+		},
+		"Modifier words": {
+			section: "5L,15W",
+			expected: []token{
+				{kind: tokenWord, text: "5L", offset: 0},
+				{kind: tokenSymbol, text: ",", offset: 2},
+				{kind: tokenWord, text: "15W", offset: 3},
+			},
+		},
+		"Multi byte names": {
+			section: "1月,März",
+			expected: []token{
+				{kind: tokenWord, text: "1月", offset: 0},
+				{kind: tokenSymbol, text: ",", offset: 4},
+				{kind: tokenWord, text: "März", offset: 5},
+			},
+		},
+		"Empty": {
+			section: "",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := lex(testCase.section)
+
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expected, got)
+			}
+		})
+	}
+}
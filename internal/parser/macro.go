@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	MacroReboot = "@reboot"
+	MacroEvery  = "@every"
+)
+
+// Nicknames of common schedules and their fields
+var Macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Jenkins nicknames spread their runs by the hashed key
+var JenkinsMacros = map[string]string{
+	"@yearly":   "H H H H *",
+	"@annually": "H H H H *",
+	"@monthly":  "H H H * *",
+	"@weekly":   "H H * * H",
+	"@daily":    "H H * * *",
+	"@midnight": "H H(0-2) * * *",
+	"@hourly":   "H * * * *",
+}
+
+// `@every <duration>`, the duration as accepted by time.ParseDuration.
+func parseEvery(rest string, expression Expression, dialect Dialect) (Expression, error) {
+	sections := splitFields(rest, 2)
+	if len(sections) == 0 {
+		return Expression{}, newError(CodeSections, "", "invalid number of sections")
+	}
+	duration := sections[0]
+
+	user, command, err := macroCommand(strings.Join(sections[1:], ""), dialect)
+	if nil != err {
+		return Expression{}, err
+	}
+
+	every, err := time.ParseDuration(duration)
+	if nil != err {
+		return Expression{}, newError(CodeDuration, duration, fmt.Sprintf("invalid duration `%s`", duration))
+	}
+	if every < time.Second {
+		return Expression{}, newError(CodeDuration, duration, fmt.Sprintf("duration `%s` shorter than 1s", duration))
+	}
+
+	expression.Every = every
+	expression.User = user
+	expression.Command = command
+	return expression, nil
+}
+
+// Values of slots macros do not mention
+var macroDefaults = map[string]string{
+	LabelSecond: "0",
+	LabelYear:   "*",
+}
+
+// Map the standard five macro fields onto the given slots by their labels.
+func expandMacro(fields string, slots []Slot) ([]string, bool) {
+	values := map[string]string{}
+	for i, value := range strings.Split(fields, " ") {
+		values[Slots[i].Label] = value
+	}
+
+	var sections []string
+	used := 0
+	for i := range slots {
+		value, ok := values[slots[i].Label]
+		if ok {
+			used++
+		} else if value, ok = macroDefaults[slots[i].Label]; !ok {
+			return nil, false
+		}
+		sections = append(sections, value)
+	}
+	return sections, used == len(values)
+}
+
+func isMacro(input string) bool {
+	return strings.HasPrefix(input, "@")
+}
+
+func parseMacro(input string, dialect Dialect, sources Sources) (Expression, error) {
+	sections := splitFields(input, 2)
+	macro := strings.ToLower(sections[0])
+	rest := strings.Join(sections[1:], "")
+	expression := Expression{Macro: macro, DayRule: dialect.DayRule}
+
+	if macro == MacroEvery && dialect.Every {
+		return parseEvery(rest, expression, dialect)
+	}
+
+	user, command, err := macroCommand(rest, dialect)
+	if nil != err {
+		return Expression{}, err
+	}
+	expression.User = user
+	expression.Command = command
+
+	if macro == MacroReboot && dialect.Reboot {
+		expression.Reboot = true
+		return expression, nil
+	}
+
+	fields, ok := dialect.Macros[macro]
+	if !ok {
+		if _, known := Macros[macro]; known || macro == MacroReboot || macro == MacroEvery {
+			return Expression{}, newError(CodeMacro, sections[0], fmt.Sprintf("macro `%s` not supported", macro))
+		}
+		return Expression{}, newError(CodeMacro, sections[0], fmt.Sprintf("unknown macro `%s`", sections[0]))
+	}
+
+	written := sections[0]
+	sections, ok = expandMacro(fields, dialect.Slots)
+	if !ok {
+		return Expression{}, newError(CodeMacro, written, fmt.Sprintf("macro `%s` not supported", macro))
+	}
+
+	results, units, err := parseSections(sections, dialect.Slots, sources)
+	expression.Results = results
+	expression.Units = units
+	// Fields of a macro are not written, the macro is
+	for _, e := range parseErrors(err) {
+		e.Token, e.Offset = written, 0
+	}
+	return expression, err
+}
+
+// What follows a macro is the user and the command, if the dialect has them.
+func macroCommand(rest string, dialect Dialect) (string, string, error) {
+	if dialect.Command == (rest == "") {
+		return "", "", newError(CodeSections, "", "invalid number of sections")
+	}
+	if !dialect.User {
+		return "", rest, nil
+	}
+
+	parts := splitFields(rest, 2)
+	if len(parts) != 2 {
+		return "", "", newError(CodeSections, "", "invalid number of sections")
+	}
+	err := validateUser(parts[0])
+	if nil != err {
+		return "", "", err
+	}
+	return parts[0], parts[1], nil
+}
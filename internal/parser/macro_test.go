@@ -0,0 +1,175 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseMacro(t *testing.T) {
+	every := func(min, max int) (items []int) {
+		for k := min; k <= max; k++ {
+			items = append(items, k)
+		}
+		return items
+	}
+
+	testCases := map[string]struct {
+		input              string
+		slots              []Slot
+		expectedExpression Expression
+		expectedErr        string
+	}{
+		"Daily": {
+			input: `@daily /usr/bin/find -x`,
+			slots: Slots,
+			expectedExpression: Expression{
+				Results: []Result{
+					{Label: "minute", Items: []int{0}},
+					{Label: "hour", Items: []int{0}},
+					{Label: "day of month", Items: every(1, 31)},
+					{Label: "month", Items: every(1, 12)},
+					{Label: "day of week", Items: every(0, 6)},
+				},
+				Units: [][]Unit{
+					{{Start: 0, End: 0}},
+					{{Start: 0, End: 0}},
+					{{Any: true, Start: 1, End: 31}},
+					{{Any: true, Start: 1, End: 12}},
+					{{Any: true, Start: 0, End: 6}},
+				},
+				Command: `/usr/bin/find -x`,
+				Macro:   "@daily",
+			},
+		},
+		"Yearly upper case": {
+			input: `@YEARLY /usr/bin/find`,
+			slots: Slots,
+			expectedExpression: Expression{
+				Results: []Result{
+					{Label: "minute", Items: []int{0}},
+					{Label: "hour", Items: []int{0}},
+					{Label: "day of month", Items: []int{1}},
+					{Label: "month", Items: []int{1}},
+					{Label: "day of week", Items: every(0, 6)},
+				},
+				Units: [][]Unit{
+					{{Start: 0, End: 0}},
+					{{Start: 0, End: 0}},
+					{{Start: 1, End: 1}},
+					{{Start: 1, End: 1}},
+					{{Any: true, Start: 0, End: 6}},
+				},
+				Command: `/usr/bin/find`,
+				Macro:   "@yearly",
+			},
+		},
+		"Hourly with seconds": {
+			input: `@hourly /usr/bin/find`,
+			slots: SecondsSlots,
+			expectedExpression: Expression{
+				Results: []Result{
+					{Label: "second", Items: []int{0}},
+					{Label: "minute", Items: []int{0}},
+					{Label: "hour", Items: every(0, 23)},
+					{Label: "day of month", Items: every(1, 31)},
+					{Label: "month", Items: every(1, 12)},
+					{Label: "day of week", Items: every(0, 6)},
+				},
+				Units: [][]Unit{
+					{{Start: 0, End: 0}},
+					{{Start: 0, End: 0}},
+					{{Any: true, Start: 0, End: 23}},
+					{{Any: true, Start: 1, End: 31}},
+					{{Any: true, Start: 1, End: 12}},
+					{{Any: true, Start: 0, End: 6}},
+				},
+				Command: `/usr/bin/find`,
+				Macro:   "@hourly",
+			},
+		},
+		"Reboot": {
+			input: `@reboot /usr/bin/find`,
+			slots: Slots,
+			expectedExpression: Expression{
+				Command: `/usr/bin/find`,
+				Macro:   "@reboot",
+				Reboot:  true,
+			},
+		},
+		"Every": {
+			input: `@every 1h30m /usr/bin/find -x`,
+			slots: Slots,
+			expectedExpression: Expression{
+				Command: `/usr/bin/find -x`,
+				Macro:   "@every",
+				Every:   90 * time.Minute,
+			},
+		},
+
+		// Errors
+
+		"Missing command": {
+			input:       `@daily`,
+			slots:       Slots,
+			expectedErr: "invalid number of sections",
+		},
+		"Unknown": {
+			input:       `@fortnightly /usr/bin/find`,
+			slots:       Slots,
+			expectedErr: "unknown macro `@fortnightly`",
+		},
+		"Every without command": {
+			input:       `@every 1h`,
+			slots:       Slots,
+			expectedErr: "invalid number of sections",
+		},
+		"Every invalid duration": {
+			input:       `@every often /usr/bin/find`,
+			slots:       Slots,
+			expectedErr: "invalid duration `often`",
+		},
+		"Every too short": {
+			input:       `@every 10ms /usr/bin/find`,
+			slots:       Slots,
+			expectedErr: "duration `10ms` shorter than 1s",
+		},
+		"Different slots": {
+			input:       `@daily /usr/bin/find`,
+			slots:       Slots[:2],
+			expectedErr: "macro `@daily` not supported",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dialect := Vixie
+			dialect.Slots = testCase.slots
+			expression, err := parseMacro(testCase.input, dialect, Sources{})
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+					return
+				}
+
+				// Ignore other results
+				return
+			}
+
+			if testCase.expectedErr == "" && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if !reflect.DeepEqual(expression, testCase.expectedExpression) {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expectedExpression, expression)
+			}
+		})
+	}
+}
@@ -1,67 +1,244 @@
 package parser
 
 import (
-	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
+// Parse parses field based expressions, for @reboot and @every no results are returned. See ParseExpression.
 func Parse(input string, slots []Slot) (results []Result, command string, err error) {
-	input = cleanInput(input)
-	n := len(slots) + 1 // Number of slots + command
-	sections := strings.SplitN(input, " ", n)
+	expression, err := ParseExpression(input, slots)
+	if nil != err {
+		return nil, "", err
+	}
+	return expression.Results, expression.Command, nil
+}
+
+// ParseExpression parses expressions of the Vixie dialect with the given slots.
+func ParseExpression(input string, slots []Slot) (Expression, error) {
+	dialect := Vixie
+	dialect.Slots = slots
+	return ParseDialect(input, dialect)
+}
 
-	if len(sections) != n {
-		return nil, "", errors.New("invalid number of sections")
+// ParseDialect parses expressions following the rules of the given dialect.
+func ParseDialect(input string, dialect Dialect) (Expression, error) {
+	return ParseWith(input, dialect, Sources{})
+}
+
+// ParseWith parses expressions of the given dialect whose values may depend on sources, e.g. the key hashed by `H`.
+// Errors are a *ParseError when the input is invalid.
+func ParseWith(input string, dialect Dialect, sources Sources) (Expression, error) {
+	expression, err := ParseAll(input, dialect, sources)
+	if errs, ok := err.(ParseErrors); ok {
+		return Expression{}, errs[0]
 	}
+	return expression, err
+}
 
-	sections, command = separateCommand(sections, n)
-	results, err = parseSections(sections, slots)
-	return results, command, err
+// ParseAll parses as ParseWith does, but reports every invalid field rather than the first one.
+// Errors are ParseErrors, results of the valid fields are returned along with them.
+// The number of fields, macros and the user are checked first, when they fail no results are returned.
+func ParseAll(input string, dialect Dialect, sources Sources) (Expression, error) {
+	trimmed := cleanInput(input)
+	expression, err := parseInput(trimmed, dialect, sources)
+	if e, ok := err.(*ParseError); ok {
+		err = ParseErrors{e}
+	}
+	return expression, shift(err, strings.Index(input, trimmed))
 }
 
-func parseSections(sections []string, slots []Slot) (results []Result, err error) {
-	for i := range sections {
-		section := sections[i]
-		slot := slots[i]
-		result := Result{Label: slot.Label}
+// Offsets of errors are relative to the input.
+func parseInput(input string, dialect Dialect, sources Sources) (Expression, error) {
+	if dialect.Wrapped {
+		if rate, offset, ok := unwrap(input, "rate"); ok {
+			expression, err := parseRate(rate, dialect)
+			return expression, locate(err, rate, offset)
+		}
+		if fields, offset, ok := unwrap(input, "cron"); ok {
+			dialect.Wrapped = false
+			expression, err := parseInput(fields, dialect, sources)
+			return expression, shift(err, offset)
+		}
+	}
+	if isMacro(input) {
+		expression, err := parseMacro(input, dialect, sources)
+		return expression, locate(err, input, 0)
+	}
+
+	sections, offsets, user, command, err := splitSections(input, dialect)
+	if nil != err {
+		return Expression{}, locate(err, input, 0)
+	}
+
+	var errs ParseErrors
+	slots := dialect.Slots[:len(sections)]
+	if dialect.QuestionMark {
+		errs = validateQuestionMark(sections, slots)
+	}
+
+	results, units, err := parseSections(sections, slots, sources)
+	errs = append(errs, parseErrors(err)...)
+	// Fields with a misplaced `?` are left out even when they parse
+	results, units = withoutFields(results, units, errs)
+
+	expression := Expression{Results: results, Units: units, Command: command, User: user, DayRule: dialect.DayRule}
+	if len(errs) > 0 {
+		return expression, locateField(errs, input, sections, offsets)
+	}
+	return expression, nil
+}
+
+// withoutFields leaves out results of fields with errors.
+func withoutFields(results []Result, units [][]Unit, errs ParseErrors) ([]Result, [][]Unit) {
+	invalid := map[string]bool{}
+	for _, e := range errs {
+		invalid[e.Label] = e.Field >= 0
+	}
+
+	var keptResults []Result
+	var keptUnits [][]Unit
+	for i := range results {
+		if invalid[results[i].Label] {
+			continue
+		}
+		keptResults = append(keptResults, results[i])
+		keptUnits = append(keptUnits, units[i])
+	}
+	return keptResults, keptUnits
+}
+
+// Trailing optional slots are only allowed in dialects without a command. Offsets of the sections are returned too.
+func splitSections(input string, dialect Dialect) (sections []string, offsets []int, user string, command string, err error) {
+	if dialect.Command {
+		n := len(dialect.Slots) + 1 // Number of slots + command
+		if dialect.User {
+			n++
+		}
+		sections, offsets = splitFieldsAt(input, n)
+		if len(sections) != n {
+			return nil, nil, "", "", newError(CodeSections, "", "invalid number of sections")
+		}
+		sections, command = separateCommand(sections, n)
+		if dialect.User {
+			sections, user = separateCommand(sections, n-1)
+			err = validateUser(user)
+			if nil != err {
+				return nil, nil, "", "", locate(err, user, offsets[n-2])
+			}
+		}
+		return sections, offsets[:len(sections)], user, command, nil
+	}
+
+	sections, offsets = splitFieldsAt(input, -1)
+	if len(sections) > len(dialect.Slots) || len(sections) < len(dialect.Slots)-dialect.Optional {
+		return nil, nil, "", "", newError(CodeSections, "", "invalid number of sections")
+	}
+	return sections, offsets, "", "", nil
+}
+
+// Longest user name most systems allow
+const maxUserLength = 32
 
-		section = normalizeValues(section, slot)
+// User names as useradd accepts them by default, upper case letters and dots included.
+var userPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9._-]*\$?$`)
+
+func validateUser(user string) error {
+	if len(user) > maxUserLength || !userPattern.MatchString(user) {
+		return newError(CodeUser, user, fmt.Sprintf("invalid user `%s`", user))
+	}
+	return nil
+}
 
-		err := validate(section, slot)
+// Units of each section are returned along with its result. Every invalid section is reported as ParseErrors,
+// results and units of the valid ones are returned as well.
+func parseSections(sections []string, slots []Slot, sources Sources) (results []Result, units [][]Unit, err error) {
+	var errs ParseErrors
+	for i := range sections {
+		result, sectionUnits, err := parseSection(sections[i], slots[i], sources)
 		if nil != err {
-			return nil, err
+			errs = append(errs, parseErrors(inField(err, i, slots[i]))...)
+			continue
 		}
+		results = append(results, result)
+		units = append(units, sectionUnits)
+	}
+	if len(errs) > 0 {
+		return results, units, errs
+	}
+	return results, units, nil
+}
+
+func parseSection(section string, slot Slot, sources Sources) (Result, []Unit, error) {
+	result := Result{Label: slot.Label}
+	w := writtenUnits(section)
+
+	section, err := normalizeValues(section, slot)
+	if nil != err {
+		return Result{}, nil, err
+	}
+
+	err = validate(section, slot)
+	if nil != err {
+		return Result{}, nil, err
+	}
+
+	section, specials, w, err := parseSpecials(section, slot, w)
+	if nil != err {
+		return Result{}, nil, err
+	}
+	result.Specials = specials
+
+	section, err = resolveHash(section, slot, sources, w)
+	if nil != err {
+		return Result{}, nil, err
+	}
 
+	section, random, err := resolveRandom(section, slot, sources, w)
+	if nil != err {
+		return Result{}, nil, err
+	}
+
+	// Nothing left when the section holds special days only
+	var units []Unit
+	if section != "" {
+		units = parseUnits(section, slot)
 		section = normalizeCharacters(section, slot)
 
-		items, err := parseJoins(section, slot)
+		items, err := parseJoins(section, slot, w)
 		if nil != err {
-			return nil, err
+			return Result{}, nil, err
 		}
 
+		for k := range items {
+			items[k] = slot.renumber(items[k])
+		}
 		result.AddItems(items)
-		results = append(results, result)
 	}
-	return results, nil
+	for k := range specials {
+		if specials[k].Kind == LastWeekday || specials[k].Kind == NthWeekday {
+			specials[k].Value = slot.renumber(specials[k].Value)
+		}
+	}
+	result.Random = random
+	return result, units, nil
 }
 
-// Normalize special values such as: Sun => 0, jan => 1 ...
-func normalizeValues(section string, slot Slot) string {
-	if slot.Replacer != nil {
-		section = strings.ToLower(section)
-		section = slot.Replacer.Replace(section)
+// Normalize names of values such as: Sun => 0, january => 1 ...
+func normalizeValues(section string, slot Slot) (string, error) {
+	if slot.Names != nil {
+		return resolveNames(section, slot)
 	}
-	return section
+	return section, nil
 }
 
 func validate(section string, slot Slot) error {
 	pattern := slot.ValidCharacters
 	match, _ := regexp.MatchString(pattern, section)
 	if !match {
-		return errors.New(fmt.Sprintf("`%s` does not match expected pattern `%s` in `%s`", section, pattern, slot.Label))
+		return newError(CodeCharacters, section, fmt.Sprintf("`%s` does not match expected pattern `%s` in `%s`", section, pattern, slot.Label))
 	}
 	return nil
 }
@@ -84,10 +261,10 @@ func parseStep(item string) (int, string, error) {
 	u := stepParts[0]
 	step, err := strconv.Atoi(stepParts[1])
 	if nil != err {
-		return 0, "", errors.New("invalid step")
+		return 0, "", newError(CodeStep, stepParts[1], "invalid step")
 	}
 	if step == 0 {
-		return 0, "", errors.New("invalid step")
+		return 0, "", newError(CodeStep, stepParts[1], "invalid step")
 	}
 	return step, u, nil
 }
@@ -98,22 +275,22 @@ func parseRange(item string, slot Slot, step int) (items []int, err error) {
 
 	start, err := strconv.Atoi(rangeParts[0])
 	if nil != err {
-		return nil, errors.New(fmt.Sprintf("invalid start in `%s`", slot.Label))
+		return nil, newError(CodeRangeStart, rangeParts[0], fmt.Sprintf("invalid start in `%s`", slot.Label))
 	}
 	if start < slot.Min {
-		return nil, errors.New(fmt.Sprintf("invalid range start in `%s`", slot.Label))
+		return nil, newError(CodeRangeStart, rangeParts[0], fmt.Sprintf("invalid range start in `%s`", slot.Label))
 	}
 
 	end, err := strconv.Atoi(rangeParts[1])
 	if nil != err {
-		return nil, errors.New(fmt.Sprintf("invalid end in `%s`", slot.Label))
+		return nil, newError(CodeRangeEnd, rangeParts[1], fmt.Sprintf("invalid end in `%s`", slot.Label))
 	}
 	if end > slot.Max {
-		return nil, errors.New(fmt.Sprintf("invalid range end in `%s`", slot.Label))
+		return nil, newError(CodeRangeEnd, rangeParts[1], fmt.Sprintf("invalid range end in `%s`", slot.Label))
 	}
 
 	if start > end {
-		return nil, errors.New(fmt.Sprintf("invalid range, start `%d` > end `%d` in `%s`", start, end, slot.Label))
+		return nil, newError(CodeRangeOrder, item, fmt.Sprintf("invalid range, start `%d` > end `%d` in `%s`", start, end, slot.Label))
 	}
 
 	rangeStep := 1
@@ -130,10 +307,10 @@ func parseRange(item string, slot Slot, step int) (items []int, err error) {
 func parseSingle(item string, slot Slot, step int) (items []int, err error) {
 	start, err := strconv.Atoi(item)
 	if nil != err {
-		return nil, errors.New(fmt.Sprintf("invalid item `%s` in `%s`", item, slot.Label))
+		return nil, newError(CodeItem, item, fmt.Sprintf("invalid item `%s` in `%s`", item, slot.Label))
 	}
 	if start < slot.Min || start > slot.Max {
-		return nil, errors.New(fmt.Sprintf("item `%s` out of range in `%s`", item, slot.Label))
+		return nil, newError(CodeOutOfRange, item, fmt.Sprintf("item `%s` out of range in `%s`", item, slot.Label))
 	}
 
 	if step > 0 {
@@ -147,20 +324,21 @@ func parseSingle(item string, slot Slot, step int) (items []int, err error) {
 	return items, nil
 }
 
-func parseJoins(section string, slot Slot) (items []int, err error) {
+// Errors are placed in the units as written in w.
+func parseJoins(section string, slot Slot, w written) (items []int, err error) {
 	units := strings.Split(section, ",")
 	for j := range units {
 		unit := units[j]
 
 		step, unit, err := parseStep(unit)
 		if nil != err {
-			return nil, errors.New(fmt.Sprintf("`%s` in `%s`", err, slot.Label))
+			return nil, w.place(within(err, slot), j)
 		}
 
 		if isRange(unit) {
 			rangeItems, err := parseRange(unit, slot, step)
 			if nil != err {
-				return nil, err
+				return nil, w.place(err, j)
 			}
 			items = append(items, rangeItems...)
 			continue
@@ -168,7 +346,7 @@ func parseJoins(section string, slot Slot) (items []int, err error) {
 
 		singleItems, err := parseSingle(unit, slot, step)
 		if nil != err {
-			return nil, err
+			return nil, w.place(err, j)
 		}
 		items = append(items, singleItems...)
 	}
@@ -2,7 +2,6 @@ package parser
 
 import (
 	"reflect"
-	"strings"
 	"testing"
 )
 
@@ -229,6 +228,127 @@ func TestParse(t *testing.T) {
 			expectedCommand: `/usr/bin/find`,
 			expectedErr:     "",
 		},
+		"Last day": {
+			input: `0 0 L * 5L /usr/bin/find`,
+			expectedResults: []Result{
+				{
+					Label: "minute",
+					Items: []int{0},
+				},
+				{
+					Label: "hour",
+					Items: []int{0},
+				},
+				{
+					Label:    "day of month",
+					Specials: []Special{{Kind: LastDay}},
+				},
+				{
+					Label: "month",
+					Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+				},
+				{
+					Label:    "day of week",
+					Specials: []Special{{Kind: LastWeekday, Value: 5}},
+				},
+			},
+			expectedCommand: `/usr/bin/find`,
+			expectedErr:     "",
+		},
+		"Last day mixed": {
+			input: `0 0 1,l-3 * friL,L /usr/bin/find`,
+			expectedResults: []Result{
+				{
+					Label: "minute",
+					Items: []int{0},
+				},
+				{
+					Label: "hour",
+					Items: []int{0},
+				},
+				{
+					Label:    "day of month",
+					Items:    []int{1},
+					Specials: []Special{{Kind: LastDay, Offset: 3}},
+				},
+				{
+					Label: "month",
+					Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+				},
+				{
+					Label:    "day of week",
+					Items:    []int{6},
+					Specials: []Special{{Kind: LastWeekday, Value: 5}},
+				},
+			},
+			expectedCommand: `/usr/bin/find`,
+			expectedErr:     "",
+		},
+		"Nearest workday": {
+			input: `0 0 15W,lw * * /usr/bin/find`,
+			expectedResults: []Result{
+				{
+					Label: "minute",
+					Items: []int{0},
+				},
+				{
+					Label: "hour",
+					Items: []int{0},
+				},
+				{
+					Label:    "day of month",
+					Specials: []Special{{Kind: NearestWorkday, Value: 15}, {Kind: LastWorkday}},
+				},
+				{
+					Label: "month",
+					Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+				},
+				{
+					Label: "day of week",
+					Items: []int{0, 1, 2, 3, 4, 5, 6},
+				},
+			},
+			expectedCommand: `/usr/bin/find`,
+			expectedErr:     "",
+		},
+		"Nth weekday": {
+			input: `0 0 * * MON#2,5#5 /usr/bin/find`,
+			expectedResults: []Result{
+				{
+					Label: "minute",
+					Items: []int{0},
+				},
+				{
+					Label: "hour",
+					Items: []int{0},
+				},
+				{
+					Label: "day of month",
+					Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+				},
+				{
+					Label: "month",
+					Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+				},
+				{
+					Label:    "day of week",
+					Specials: []Special{{Kind: NthWeekday, Value: 1, Nth: 2}, {Kind: NthWeekday, Value: 5, Nth: 5}},
+				},
+			},
+			expectedCommand: `/usr/bin/find`,
+			expectedErr:     "",
+		},
+		"Tabs and double spaces": {
+			input: "0\t0  1 *\t\t1   /usr/bin/find  -name \t x",
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{0}},
+				{Label: "hour", Items: []int{0}},
+				{Label: "day of month", Items: []int{1}},
+				{Label: "month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+				{Label: "day of week", Items: []int{1}},
+			},
+			expectedCommand: "/usr/bin/find  -name \t x",
+		},
 
 		// Errors
 
@@ -261,6 +381,34 @@ func TestParse(t *testing.T) {
 			input:       `1/0 * * * * /usr/bin/find`,
 			expectedErr: "`invalid step` in `minute`",
 		},
+		"Last day offset out of range": {
+			input:       `* * L-31 * * /usr/bin/find`,
+			expectedErr: "invalid item `L-31` in `day of month`",
+		},
+		"Last weekday out of range": {
+			input:       `* * * * 7L /usr/bin/find`,
+			expectedErr: "invalid item `7L` in `day of week`",
+		},
+		"Nearest workday out of range": {
+			input:       `* * 32W * * /usr/bin/find`,
+			expectedErr: "invalid item `32W` in `day of month`",
+		},
+		"Nearest workday range": {
+			input:       `* * 1-5W * * /usr/bin/find`,
+			expectedErr: "invalid item `1-5W` in `day of month`",
+		},
+		"Nth weekday occurrence out of range": {
+			input:       `* * * * 1#6 /usr/bin/find`,
+			expectedErr: "occurrence `6` out of range 1-5 in `day of week`",
+		},
+		"Nth weekday invalid day": {
+			input:       `* * * * 9#1 /usr/bin/find`,
+			expectedErr: "invalid item `9#1` in `day of week`",
+		},
+		"Last not allowed": {
+			input:       `* L * * * /usr/bin/find`,
+			expectedErr: "`L` does not match expected pattern `^[\\d|\\*|\\-|,|/]+$` in `hour`",
+		},
 
 		// TODO: many other cases...
 	}
@@ -304,27 +452,111 @@ func TestParse(t *testing.T) {
 
 func TestNormalizeValues(t *testing.T) {
 	testCases := map[string]struct {
-		section  string
-		slot     Slot
-		expected string
+		section     string
+		slot        Slot
+		expected    string
+		expectedErr string
 	}{
-		"No replacer": {
+		"No names": {
 			section:  "123",
 			slot:     Slot{},
 			expected: "123",
 		},
-		"Replacer": {
-			section: "old string",
-			slot: Slot{
-				Replacer: strings.NewReplacer("old", "new"),
-			},
-			expected: "new string",
+		"Names": {
+			section:  "jan-Mar,DEC",
+			slot:     Slots[3],
+			expected: "1-3,12",
+		},
+		"Full names": {
+			section:  "Monday-friday/2",
+			slot:     Slots[4],
+			expected: "1-5/2",
+		},
+		"Last weekday name": {
+			section:  "friL,L",
+			slot:     Slots[4],
+			expected: "5L,L",
+		},
+		"Thursday": {
+			section:  "thu,Thursday",
+			slot:     Slots[4],
+			expected: "4,4",
+		},
+		"Modifier words": {
+			section:  "L-3,LW,15w",
+			slot:     Slots[2],
+			expected: "L-3,LW,15w",
+		},
+		"Nth weekday name": {
+			section:  "mon#2",
+			slot:     Slots[4],
+			expected: "1#2",
+		},
+		"Random range of names": {
+			section:  "jan~mar",
+			slot:     OpenBSDSlots[3],
+			expected: "1~3",
+		},
+		"Added names": {
+			section:  "Montag-Freitag",
+			slot:     quartzDayOfWeekSlot.WithNames(Names{"Montag": 1, "freitag": 5}),
+			expected: "2-6",
+		},
+		"Names with digits": {
+			section:  "1月,12月",
+			slot:     Slots[3].WithNames(Names{"1月": 1, "12月": 12}),
+			expected: "1,12",
+		},
+
+		// Errors
+
+		"Name within a word": {
+			section:     "1,xjanx",
+			slot:        Slots[3],
+			expectedErr: "unrecognized token `xjanx` in `month`",
+		},
+		"Repeated name": {
+			section:     "janjan",
+			slot:        Slots[3],
+			expectedErr: "unrecognized token `janjan` in `month`",
+		},
+		"Old Thursday abbreviation": {
+			section:     "mon-thr",
+			slot:        Slots[4],
+			expectedErr: "unrecognized token `thr` in `day of week`",
+		},
+		"Name as step": {
+			section:     "*/feb",
+			slot:        Slots[3],
+			expectedErr: "unrecognized token `feb` in `month`",
+		},
+		"Modifier not allowed": {
+			section:     "5L",
+			slot:        Slots[3],
+			expectedErr: "unrecognized token `5L` in `month`",
 		},
 	}
 
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
-			got := normalizeValues(testCase.section, testCase.slot)
+			got, err := normalizeValues(testCase.section, testCase.slot)
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
 
 			if got != testCase.expected {
 				t.Errorf("expected: %v\nbut got: %v", testCase.expected, got)
@@ -472,7 +704,7 @@ func TestParseSection(t *testing.T) {
 
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
-			results, err := parseSections(testCase.sections, testCase.slots)
+			results, _, err := parseSections(testCase.sections, testCase.slots, Sources{})
 
 			if testCase.expectedErr != "" {
 				if err == nil {
@@ -698,7 +930,7 @@ func TestParseJoins(t *testing.T) {
 
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
-			items, err := parseJoins(testCase.section, testCase.slot)
+			items, err := parseJoins(testCase.section, testCase.slot, writtenUnits(testCase.section))
 
 			if testCase.expectedErr != "" {
 				if err == nil {
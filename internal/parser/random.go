@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Random is an OpenBSD `a~b` unit, a value picked from the range when the expression is loaded.
+type Random struct {
+	Min int
+	Max int
+	// Picked value, unset when ranges are reported instead
+	Value int
+}
+
+func (r Random) String() string {
+	return fmt.Sprintf("%d~%d", r.Min, r.Max)
+}
+
+// Replace OpenBSD `~`, `a~`, `~b` and `a~b` units with a random value of the range,
+// or with the whole range when sources ask for it.
+func resolveRandom(section string, slot Slot, sources Sources, w written) (string, []Random, error) {
+	if slot.Modifiers&ModifierRandom == 0 || !strings.Contains(section, "~") {
+		return section, nil, nil
+	}
+
+	// One source for every field, sources seeded in the same clock tick would pick alike
+	intn := rand.Intn
+	if sources.Rand != nil {
+		intn = sources.Rand.Intn
+	}
+
+	var randoms []Random
+	units := strings.Split(section, ",")
+	for j := range units {
+		if !strings.Contains(units[j], "~") {
+			continue
+		}
+
+		bounds := strings.SplitN(units[j], "~", 2)
+		for k, limit := range []int{slot.Min, slot.Max} {
+			if bounds[k] == "" {
+				bounds[k] = strconv.Itoa(limit)
+			}
+		}
+		items, err := parseRange(strings.Join(bounds, "-"), slot, 0)
+		if nil != err {
+			return "", nil, w.place(err, j)
+		}
+
+		min, max := items[0], items[len(items)-1]
+		random := Random{Min: slot.renumber(min), Max: slot.renumber(max)}
+		if sources.RandomRanges {
+			units[j] = fmt.Sprintf("%d-%d", min, max)
+		} else {
+			value := items[intn(len(items))]
+			random.Value = slot.renumber(value)
+			units[j] = strconv.Itoa(value)
+		}
+		randoms = append(randoms, random)
+	}
+	return strings.Join(units, ","), randoms, nil
+}
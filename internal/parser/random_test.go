@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestResolveRandom(t *testing.T) {
+	testCases := map[string]struct {
+		input           string
+		ranges          bool
+		expectedResults []Result
+		expectedErr     string
+	}{
+		"Picked": {
+			input: `0~30 ~ * * mon~fri /usr/bin/find`,
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{27}, Random: []Random{{Min: 0, Max: 30, Value: 27}}},
+				{Label: "hour", Items: []int{15}, Random: []Random{{Min: 0, Max: 23, Value: 15}}},
+				{Label: "day of month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}},
+				{Label: "month", Items: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+				{Label: "day of week", Items: []int{3}, Random: []Random{{Min: 1, Max: 5, Value: 3}}},
+			},
+		},
+		"Open bounds in a list": {
+			input: `~10,30 1~ 1 1 * /usr/bin/find`,
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{1, 30}, Random: []Random{{Min: 0, Max: 10, Value: 1}}},
+				{Label: "hour", Items: []int{23}, Random: []Random{{Min: 1, Max: 23, Value: 23}}},
+				{Label: "day of month", Items: []int{1}},
+				{Label: "month", Items: []int{1}},
+				{Label: "day of week", Items: []int{0, 1, 2, 3, 4, 5, 6}},
+			},
+		},
+		"Ranges": {
+			input:  `50~ 0 1 1 mon~wed /usr/bin/find`,
+			ranges: true,
+			expectedResults: []Result{
+				{Label: "minute", Items: []int{50, 51, 52, 53, 54, 55, 56, 57, 58, 59}, Random: []Random{{Min: 50, Max: 59}}},
+				{Label: "hour", Items: []int{0}},
+				{Label: "day of month", Items: []int{1}},
+				{Label: "month", Items: []int{1}},
+				{Label: "day of week", Items: []int{1, 2, 3}, Random: []Random{{Min: 1, Max: 3}}},
+			},
+		},
+
+		// Errors
+
+		"Reversed": {
+			input:       `30~10 * * * * /usr/bin/find`,
+			expectedErr: "invalid range, start `30` > end `10` in `minute`",
+		},
+		"Out of range": {
+			input:       `* 0~24 * * * /usr/bin/find`,
+			expectedErr: "invalid range end in `hour`",
+		},
+		"With step": {
+			input:       `0~30/5 * * * * /usr/bin/find`,
+			expectedErr: "invalid end in `minute`",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sources := Sources{Rand: rand.New(rand.NewSource(1)), RandomRanges: testCase.ranges}
+			expression, err := ParseWith(testCase.input, OpenBSD, sources)
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if !reflect.DeepEqual(expression.Results, testCase.expectedResults) {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expectedResults, expression.Results)
+			}
+		})
+	}
+}
@@ -2,60 +2,239 @@ package parser
 
 import "strings"
 
+// Slot labels
+const (
+	LabelSecond     = "second"
+	LabelMinute     = "minute"
+	LabelHour       = "hour"
+	LabelDayOfMonth = "day of month"
+	LabelMonth      = "month"
+	LabelDayOfWeek  = "day of week"
+	LabelYear       = "year"
+)
+
 // Ordered list of cron parts
 var Slots = []Slot{
 	{
-		Label:           "minute",
+		Label:           LabelMinute,
 		Min:             0,
 		Max:             59,
 		ValidCharacters: `^[\d|\*|\-|,|/]+$`,
 	},
 	{
-		Label:           "hour",
+		Label:           LabelHour,
 		Min:             0,
 		Max:             23,
 		ValidCharacters: `^[\d|\*|\-|,|/]+$`,
 	},
 	{
-		Label:           "day of month",
+		Label:           LabelDayOfMonth,
 		Min:             1,
 		Max:             31,
-		ValidCharacters: `^[\d|\*|\-|,|/|?]+$`,
+		ValidCharacters: `^[\d|\*|\-|,|/|?|L|l|W|w]+$`,
+		Modifiers:       ModifierLast | ModifierLastOffset | ModifierWeekday,
 	},
 	{
-		Label:           "month",
+		Label:           LabelMonth,
 		Min:             1,
 		Max:             12,
 		ValidCharacters: `^[\d|\*|\-|,|/]+$`,
-		Replacer: strings.NewReplacer(
-			"jan", "1",
-			"feb", "2",
-			"mar", "3",
-			"apr", "4",
-			"may", "5",
-			"jun", "6",
-			"jul", "7",
-			"aug", "8",
-			"sep", "9",
-			"oct", "10",
-			"nov", "11",
-			"dec", "12",
-		),
-	},
-	{
-		Label:           "day of week",
+		Names:           monthNames,
+	},
+	{
+		Label:           LabelDayOfWeek,
+		Min:             0,
+		Max:             6,
+		ValidCharacters: `^[\d|\*|\-|,|/|?|L|l|#]+$`,
+		Modifiers:       ModifierLast | ModifierNth,
+		Names:           dayOfWeekNames,
+	},
+}
+
+// English month names and their abbreviations
+var monthNames = Names{
+	"jan": 1, "january": 1,
+	"feb": 2, "february": 2,
+	"mar": 3, "march": 3,
+	"apr": 4, "april": 4,
+	"may": 5,
+	"jun": 6, "june": 6,
+	"jul": 7, "july": 7,
+	"aug": 8, "august": 8,
+	"sep": 9, "september": 9,
+	"oct": 10, "october": 10,
+	"nov": 11, "november": 11,
+	"dec": 12, "december": 12,
+}
+
+// English weekday names and their abbreviations, Sunday = 0
+var dayOfWeekNames = Names{
+	"sun": 0, "sunday": 0,
+	"mon": 1, "monday": 1,
+	"tue": 2, "tuesday": 2,
+	"wed": 3, "wednesday": 3,
+	"thu": 4, "thursday": 4,
+	"fri": 5, "friday": 5,
+	"sat": 6, "saturday": 6,
+}
+
+// Copy of the English month names
+func MonthNames() Names {
+	return monthNames.clone()
+}
+
+// Copy of the English weekday names, Sunday = 0
+func DayOfWeekNames() Names {
+	return dayOfWeekNames.clone()
+}
+
+// English weekday names numbered 1-7 from Sunday
+var quartzDayOfWeekNames = Names{
+	"sun": 1, "sunday": 1,
+	"mon": 2, "monday": 2,
+	"tue": 3, "tuesday": 3,
+	"wed": 4, "wednesday": 4,
+	"thu": 5, "thursday": 5,
+	"fri": 6, "friday": 6,
+	"sat": 7, "saturday": 7,
+}
+
+var secondSlot = Slot{
+	Label:           LabelSecond,
+	Min:             0,
+	Max:             59,
+	ValidCharacters: `^[\d|\*|\-|,|/]+$`,
+}
+
+// Ordered list of cron parts with seconds first
+var SecondsSlots = append([]Slot{secondSlot}, Slots...)
+
+var quartzDayOfWeekSlot = Slot{
+	Label:           LabelDayOfWeek,
+	Min:             1,
+	Max:             7,
+	ValidCharacters: `^[\d|\*|\-|,|/|?|L|l|#]+$`,
+	Modifiers:       ModifierLast | ModifierNth,
+	Names:           quartzDayOfWeekNames,
+	// Results use Sunday = 0 as the other slots do
+	Shift: -1,
+}
+
+var yearSlot = Slot{
+	Label:           LabelYear,
+	Min:             1970,
+	Max:             2099,
+	ValidCharacters: `^[\d|\*|\-|,|/]+$`,
+}
+
+// Ordered list of Quartz scheduler parts, the year is optional
+var QuartzSlots = []Slot{secondSlot, Slots[0], Slots[1], Slots[2], Slots[3], quartzDayOfWeekSlot, yearSlot}
+
+// Ordered list of POSIX crontab parts, numbers, lists and ranges only
+var POSIXSlots = []Slot{
+	{
+		Label:           LabelMinute,
+		Min:             0,
+		Max:             59,
+		ValidCharacters: `^[\d|\*|\-|,]+$`,
+	},
+	{
+		Label:           LabelHour,
+		Min:             0,
+		Max:             23,
+		ValidCharacters: `^[\d|\*|\-|,]+$`,
+	},
+	{
+		Label:           LabelDayOfMonth,
+		Min:             1,
+		Max:             31,
+		ValidCharacters: `^[\d|\*|\-|,]+$`,
+	},
+	{
+		Label:           LabelMonth,
+		Min:             1,
+		Max:             12,
+		ValidCharacters: `^[\d|\*|\-|,]+$`,
+	},
+	{
+		Label:           LabelDayOfWeek,
 		Min:             0,
 		Max:             6,
-		ValidCharacters: `^[\d|\*|\-|,|/|?]+$`,
-		Replacer: strings.NewReplacer(
-			"sun", "0",
-			"mon", "1",
-			"tue", "2",
-			"wed", "3",
-			"thr", "4",
-			"fri", "5",
-			"sat", "6",
-		),
+		ValidCharacters: `^[\d|\*|\-|,]+$`,
+	},
+}
+
+// EventBridge has no `L-n`
+var awsDayOfMonthSlot = func() Slot {
+	slot := Slots[2]
+	slot.Modifiers &^= ModifierLastOffset
+	return slot
+}()
+
+// Ordered list of AWS EventBridge cron parts, without seconds and with a mandatory year
+var AWSSlots = []Slot{
+	Slots[0],
+	Slots[1],
+	awsDayOfMonthSlot,
+	Slots[3],
+	quartzDayOfWeekSlot,
+	{
+		Label:           LabelYear,
+		Min:             1970,
+		Max:             2199,
+		ValidCharacters: `^[\d|\*|\-|,|/]+$`,
+	},
+}
+
+// Ordered list of Jenkins trigger parts, `H` spreads values by a hashed key and day of week 7 is Sunday as well
+var JenkinsSlots = []Slot{
+	{
+		Label:           LabelMinute,
+		Min:             0,
+		Max:             59,
+		ValidCharacters: `^[\d|\*|\-|,|/|H|\(|\)]+$`,
+		Modifiers:       ModifierHash,
+	},
+	{
+		Label:           LabelHour,
+		Min:             0,
+		Max:             23,
+		ValidCharacters: `^[\d|\*|\-|,|/|H|\(|\)]+$`,
+		Modifiers:       ModifierHash,
+	},
+	{
+		Label:           LabelDayOfMonth,
+		Min:             1,
+		Max:             31,
+		ValidCharacters: `^[\d|\*|\-|,|/|H|\(|\)]+$`,
+		Modifiers:       ModifierHash,
+	},
+	{
+		Label:           LabelMonth,
+		Min:             1,
+		Max:             12,
+		ValidCharacters: `^[\d|\*|\-|,|/|H|\(|\)]+$`,
+		Modifiers:       ModifierHash,
+	},
+	{
+		Label:           LabelDayOfWeek,
+		Min:             0,
+		Max:             7,
+		ValidCharacters: `^[\d|\*|\-|,|/|H|\(|\)]+$`,
+		Modifiers:       ModifierHash,
+		Modulo:          7,
 	},
 }
 
+// Ordered list of OpenBSD cron parts, `~` picks a random value
+var OpenBSDSlots = withModifier(Slots, ModifierRandom, "~")
+
+// Copy slots allowing one more modifier and its character.
+func withModifier(slots []Slot, modifier Modifier, character string) (result []Slot) {
+	for _, slot := range slots {
+		slot.ValidCharacters = strings.Replace(slot.ValidCharacters, "]+$", "|"+character+"]+$", 1)
+		slot.Modifiers |= modifier
+		result = append(result, slot)
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Separate units with modifiers such as `L` from the rest of the section, w keeps the written units of the rest.
+func parseSpecials(section string, slot Slot, w written) (string, []Special, written, error) {
+	if slot.Modifiers == 0 {
+		return section, nil, w, nil
+	}
+
+	var rest []string
+	var restWritten written
+	var specials []Special
+	units := strings.Split(section, ",")
+	for j := range units {
+		unit := strings.ToUpper(units[j])
+
+		var special Special
+		var err error
+		switch {
+		case slot.Modifiers&ModifierNth != 0 && strings.Contains(unit, "#"):
+			special, err = parseNth(unit, slot)
+		case slot.Modifiers&ModifierWeekday != 0 && strings.HasSuffix(unit, "W"):
+			special, err = parseWeekday(unit, slot)
+		// Plain `L` in day of week is its last day
+		case slot.Modifiers&ModifierLast != 0 && unit == "L" && slot.Label == LabelDayOfWeek:
+			rest = append(rest, strconv.Itoa(slot.Max))
+			restWritten = restWritten.with(w, j)
+			continue
+		case slot.Modifiers&ModifierLast != 0 && strings.Contains(unit, "L"):
+			special, err = parseLast(unit, slot)
+		default:
+			rest = append(rest, units[j])
+			restWritten = restWritten.with(w, j)
+			continue
+		}
+
+		if nil != err {
+			return "", nil, written{}, w.place(err, j)
+		}
+		specials = append(specials, special)
+	}
+
+	return strings.Join(rest, ","), specials, restWritten, nil
+}
+
+// `L` and `L-n` in day of month, `nL` in day of week.
+func parseLast(unit string, slot Slot) (Special, error) {
+	invalid := newError(CodeItem, unit, fmt.Sprintf("invalid item `%s` in `%s`", unit, slot.Label))
+
+	switch slot.Label {
+	case LabelDayOfMonth:
+		if unit == "L" {
+			return Special{Kind: LastDay}, nil
+		}
+		if !strings.HasPrefix(unit, "L-") || slot.Modifiers&ModifierLastOffset == 0 {
+			return Special{}, invalid
+		}
+		offset, err := strconv.Atoi(unit[2:])
+		if nil != err || offset < 0 || offset > slot.Max-slot.Min {
+			return Special{}, invalid
+		}
+		return Special{Kind: LastDay, Offset: offset}, nil
+
+	case LabelDayOfWeek:
+		if !strings.HasSuffix(unit, "L") {
+			return Special{}, invalid
+		}
+		day, err := strconv.Atoi(unit[:len(unit)-1])
+		if nil != err || day < slot.Min || day > slot.Max {
+			return Special{}, invalid
+		}
+		return Special{Kind: LastWeekday, Value: day}, nil
+	}
+
+	return Special{}, invalid
+}
+
+// `nW` and `LW` in day of month.
+func parseWeekday(unit string, slot Slot) (Special, error) {
+	invalid := newError(CodeItem, unit, fmt.Sprintf("invalid item `%s` in `%s`", unit, slot.Label))
+	if slot.Label != LabelDayOfMonth {
+		return Special{}, invalid
+	}
+
+	if unit == "LW" {
+		return Special{Kind: LastWorkday}, nil
+	}
+	day, err := strconv.Atoi(unit[:len(unit)-1])
+	if nil != err || day < slot.Min || day > slot.Max {
+		return Special{}, invalid
+	}
+	return Special{Kind: NearestWorkday, Value: day}, nil
+}
+
+// Maximum number of occurrences of a weekday in a month
+const maxNth = 5
+
+// `d#n` in day of week.
+func parseNth(unit string, slot Slot) (Special, error) {
+	invalid := newError(CodeItem, unit, fmt.Sprintf("invalid item `%s` in `%s`", unit, slot.Label))
+	if slot.Label != LabelDayOfWeek {
+		return Special{}, invalid
+	}
+
+	parts := strings.SplitN(unit, "#", 2)
+	day, err := strconv.Atoi(parts[0])
+	if nil != err || day < slot.Min || day > slot.Max {
+		return Special{}, invalid
+	}
+	nth, err := strconv.Atoi(parts[1])
+	if nil != err {
+		return Special{}, invalid
+	}
+	if nth < 1 || nth > maxNth {
+		return Special{}, newError(CodeOutOfRange, unit, fmt.Sprintf("occurrence `%d` out of range 1-%d in `%s`", nth, maxNth, slot.Label))
+	}
+	return Special{Kind: NthWeekday, Value: day, Nth: nth}, nil
+}
@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSpecials(t *testing.T) {
+	testCases := map[string]struct {
+		section          string
+		slot             Slot
+		expectedSection  string
+		expectedSpecials []Special
+		expectedErr      string
+	}{
+		"No modifiers": {
+			section:         "1,L",
+			slot:            Slot{Label: LabelDayOfMonth},
+			expectedSection: "1,L",
+		},
+		"Nothing special": {
+			section:         "1-5,7",
+			slot:            Slot{Label: LabelDayOfMonth, Modifiers: ModifierLast},
+			expectedSection: "1-5,7",
+		},
+		"Last day": {
+			section:          "1,L,L-2",
+			slot:             Slot{Label: LabelDayOfMonth, Min: 1, Max: 31, Modifiers: ModifierLast | ModifierLastOffset},
+			expectedSection:  "1",
+			expectedSpecials: []Special{{Kind: LastDay}, {Kind: LastDay, Offset: 2}},
+		},
+		"Last weekday": {
+			section:          "5l",
+			slot:             Slot{Label: LabelDayOfWeek, Min: 0, Max: 6, Modifiers: ModifierLast},
+			expectedSection:  "",
+			expectedSpecials: []Special{{Kind: LastWeekday, Value: 5}},
+		},
+		"Last day of week": {
+			section:         "L",
+			slot:            Slot{Label: LabelDayOfWeek, Min: 0, Max: 6, Modifiers: ModifierLast},
+			expectedSection: "6",
+		},
+		"Nearest workday": {
+			section:          "1W,LW,3",
+			slot:             Slot{Label: LabelDayOfMonth, Min: 1, Max: 31, Modifiers: ModifierLast | ModifierWeekday},
+			expectedSection:  "3",
+			expectedSpecials: []Special{{Kind: NearestWorkday, Value: 1}, {Kind: LastWorkday}},
+		},
+		"Workday not allowed": {
+			section:         "1W",
+			slot:            Slot{Label: LabelDayOfMonth, Min: 1, Max: 31, Modifiers: ModifierLast},
+			expectedSection: "1W",
+		},
+		"Invalid nearest workday": {
+			section:     "0W",
+			slot:        Slot{Label: LabelDayOfMonth, Min: 1, Max: 31, Modifiers: ModifierWeekday},
+			expectedErr: "invalid item `0W` in `day of month`",
+		},
+		"Nth weekday": {
+			section:          "1#1,2",
+			slot:             Slot{Label: LabelDayOfWeek, Min: 0, Max: 6, Modifiers: ModifierNth},
+			expectedSection:  "2",
+			expectedSpecials: []Special{{Kind: NthWeekday, Value: 1, Nth: 1}},
+		},
+		"Invalid nth weekday": {
+			section:     "1#0",
+			slot:        Slot{Label: LabelDayOfWeek, Min: 0, Max: 6, Modifiers: ModifierNth},
+			expectedErr: "occurrence `0` out of range 1-5 in `day of week`",
+		},
+		"Nth weekday in day of month": {
+			section:     "1#1",
+			slot:        Slot{Label: LabelDayOfMonth, Min: 1, Max: 31, Modifiers: ModifierNth},
+			expectedErr: "invalid item `1#1` in `day of month`",
+		},
+		"Invalid day of month": {
+			section:     "5L",
+			slot:        Slot{Label: LabelDayOfMonth, Min: 1, Max: 31, Modifiers: ModifierLast},
+			expectedErr: "invalid item `5L` in `day of month`",
+		},
+		"Invalid day of week": {
+			section:     "L-2",
+			slot:        Slot{Label: LabelDayOfWeek, Min: 0, Max: 6, Modifiers: ModifierLast},
+			expectedErr: "invalid item `L-2` in `day of week`",
+		},
+		"Days before last without offsets": {
+			section:     "L-2",
+			slot:        Slot{Label: LabelDayOfMonth, Min: 1, Max: 31, Modifiers: ModifierLast},
+			expectedErr: "invalid item `L-2` in `day of month`",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			section, specials, _, err := parseSpecials(testCase.section, testCase.slot, writtenUnits(testCase.section))
+
+			if testCase.expectedErr != "" {
+				if err == nil {
+					t.Errorf("expected error: %v\nbut got nothing", testCase.expectedErr)
+					return
+				}
+
+				if err.Error() != testCase.expectedErr {
+					t.Errorf("expected error: %v\nbut got: %v", testCase.expectedErr, err)
+					return
+				}
+
+				// Ignore other results
+				return
+			}
+
+			if testCase.expectedErr == "" && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+				return
+			}
+
+			if section != testCase.expectedSection {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expectedSection, section)
+			}
+
+			if !reflect.DeepEqual(specials, testCase.expectedSpecials) {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expectedSpecials, specials)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"fmt"
 	"sort"
 	"strings"
+	"time"
 )
 
 type Slot struct {
@@ -10,12 +12,98 @@ type Slot struct {
 	Min             int
 	Max             int
 	ValidCharacters string
-	Replacer        *strings.Replacer
+	// Names accepted in place of values, matched as whole words regardless of case
+	Names     Names
+	Modifiers Modifier
+	// Added to parsed values so results use the standard numbering, e.g. Sunday = 0
+	Shift int
+	// Parsed values are taken modulo it after the shift, e.g. 7 makes day of week 7 a Sunday
+	Modulo int
+}
+
+// Map a parsed value to the standard numbering.
+func (s Slot) renumber(value int) int {
+	value += s.Shift
+	if s.Modulo > 0 {
+		value %= s.Modulo
+	}
+	return value
+}
+
+// Map the bounds of a range to the standard numbering. The end is only shifted, so that it stays
+// after the start, and a range wrapping over the whole modulo becomes all of it.
+func (s Slot) renumberRange(start, end int) (int, int) {
+	if s.Modulo == 0 || start == end {
+		return s.renumber(start), s.renumber(end)
+	}
+	if end-start+1 >= s.Modulo {
+		return 0, s.Modulo - 1
+	}
+	first := s.renumber(start)
+	return first, first + end - start
+}
+
+// WithNames returns a copy of the slot accepting more names, e.g. of another language.
+// Values use the standard numbering of results, e.g. Sunday = 0. Slots without names are returned as they are.
+func (s Slot) WithNames(names Names) Slot {
+	if s.Names == nil {
+		return s
+	}
+
+	merged := s.Names.clone()
+	for name, value := range names {
+		merged[strings.ToLower(name)] = value - s.Shift
+	}
+	s.Names = merged
+	return s
+}
+
+// Names maps lower case names to values of a slot, e.g. "jan" and "january" to 1.
+type Names map[string]int
+
+func (n Names) clone() Names {
+	names := Names{}
+	for name, value := range n {
+		names[name] = value
+	}
+	return names
+}
+
+// Modifier is a set of special characters allowed in a slot on top of the common ones.
+type Modifier int
+
+const (
+	ModifierLast       Modifier = 1 << iota // `L`
+	ModifierWeekday                         // `W`
+	ModifierNth                             // `#`
+	ModifierHash                            // `H`
+	ModifierRandom                          // `~`
+	ModifierLastOffset                      // `L-n` in day of month, along with ModifierLast
+)
+
+// Expression is a parsed cron line. Reboot and interval expressions have no results.
+type Expression struct {
+	Results []Result
+	// Units of each result as written, e.g. `1-5` rather than the expanded items
+	Units   [][]Unit
+	Command string
+	// User the command runs as in system crontabs
+	User string
+	// Macro the expression was written as, e.g. `@daily`
+	Macro  string
+	Reboot bool
+	// Interval of `@every` expressions
+	Every time.Duration
+	// How day of month and day of week combine, set by the dialect
+	DayRule DayRule
 }
 
 type Result struct {
-	Label string
-	Items []int
+	Label    string
+	Items    []int
+	Specials []Special
+	// OpenBSD `~` units, their values are in Items
+	Random []Random
 }
 
 func (c *Result) AddItems(items []int) {
@@ -23,3 +111,43 @@ func (c *Result) AddItems(items []int) {
 	sort.Ints(items)
 	c.Items = items
 }
+
+// Special is a day that depends on the month, so it can not be listed in Items.
+type Special struct {
+	Kind SpecialKind
+	// Day of week for LastWeekday and NthWeekday, day of month for NearestWorkday
+	Value int
+	// Number of days before the last day of month for LastDay
+	Offset int
+	// Occurrence within the month for NthWeekday
+	Nth int
+}
+
+type SpecialKind int
+
+const (
+	LastDay        SpecialKind = iota + 1 // `L`, `L-3`
+	LastWeekday                           // `5L`
+	NearestWorkday                        // `15W`
+	LastWorkday                           // `LW`
+	NthWeekday                            // `1#2`
+)
+
+func (s Special) String() string {
+	switch s.Kind {
+	case LastDay:
+		if s.Offset > 0 {
+			return fmt.Sprintf("L-%d", s.Offset)
+		}
+		return "L"
+	case LastWeekday:
+		return fmt.Sprintf("%dL", s.Value)
+	case NearestWorkday:
+		return fmt.Sprintf("%dW", s.Value)
+	case LastWorkday:
+		return "LW"
+	case NthWeekday:
+		return fmt.Sprintf("%d#%d", s.Value, s.Nth)
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Unit is one comma separated part of a section as written, kept to describe the section.
+// Start and End are renumbered as result items are, a range is kept in order when its end wraps,
+// e.g. Jenkins `5-7` is 5-7 and `0-7` the whole week 0-6.
+type Unit struct {
+	// `*` or `?`, the unit covers the slot
+	Any   bool
+	Start int
+	End   int
+	// Zero without a step
+	Step int
+}
+
+// parseUnits reads units of a section parseJoins accepted, before `*` is expanded.
+func parseUnits(section string, slot Slot) (units []Unit) {
+	for _, item := range strings.Split(section, ",") {
+		step, item, _ := parseStep(item)
+		unit := Unit{Start: slot.Min, End: slot.Max, Step: step}
+		switch {
+		case item == "*" || item == "?":
+			unit.Any = true
+		case isRange(item):
+			bounds := strings.SplitN(item, "-", 2)
+			unit.Start, _ = strconv.Atoi(bounds[0])
+			unit.End, _ = strconv.Atoi(bounds[1])
+		default:
+			// A single value with a step runs to the end of the slot
+			unit.Start, _ = strconv.Atoi(item)
+			if step == 0 {
+				unit.End = unit.Start
+			}
+		}
+		unit.Start, unit.End = slot.renumberRange(unit.Start, unit.End)
+		units = append(units, unit)
+	}
+	return units
+}
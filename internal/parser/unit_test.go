@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseUnits(t *testing.T) {
+	testCases := map[string]struct {
+		section       string
+		slot          Slot
+		expectedUnits []Unit
+	}{
+		"Any with step": {
+			section:       "*/15",
+			slot:          Slots[0],
+			expectedUnits: []Unit{{Any: true, Start: 0, End: 59, Step: 15}},
+		},
+		"List": {
+			section:       "1,15",
+			slot:          Slots[2],
+			expectedUnits: []Unit{{Start: 1, End: 1}, {Start: 15, End: 15}},
+		},
+		"Range with step": {
+			section:       "8-17/2",
+			slot:          Slots[1],
+			expectedUnits: []Unit{{Start: 8, End: 17, Step: 2}},
+		},
+		"Single with step": {
+			section:       "5/20",
+			slot:          Slots[0],
+			expectedUnits: []Unit{{Start: 5, End: 59, Step: 20}},
+		},
+		"Renumbered": {
+			section:       "2-6",
+			slot:          quartzDayOfWeekSlot,
+			expectedUnits: []Unit{{Start: 1, End: 5}},
+		},
+		"Whole week with Sunday twice": {
+			section:       "*,0-7,7",
+			slot:          JenkinsSlots[4],
+			expectedUnits: []Unit{{Any: true, Start: 0, End: 6}, {Start: 0, End: 6}, {Start: 0, End: 0}},
+		},
+		"Range ending on Sunday": {
+			section:       "5-7",
+			slot:          JenkinsSlots[4],
+			expectedUnits: []Unit{{Start: 5, End: 7}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			units := parseUnits(testCase.section, testCase.slot)
+
+			if !reflect.DeepEqual(units, testCase.expectedUnits) {
+				t.Errorf("expected: %v\nbut got: %v", testCase.expectedUnits, units)
+			}
+		})
+	}
+}
@@ -25,4 +25,29 @@ func isRange(s string) bool {
 	return strings.Contains(s, "-")
 }
 
+// Fields of a crontab line are separated by spaces or tabs
+const blank = " \t"
 
+// splitFields splits input at runs of blanks into at most n parts, the last one keeps the rest verbatim.
+// A negative n splits all of input.
+func splitFields(input string, n int) []string {
+	parts, _ := splitFieldsAt(input, n)
+	return parts
+}
+
+// splitFieldsAt splits input as splitFields does and returns the byte offsets of the parts as well.
+func splitFieldsAt(input string, n int) (parts []string, offsets []int) {
+	for i := 0; i < len(input); {
+		if strings.IndexByte(blank, input[i]) >= 0 {
+			i++
+			continue
+		}
+		end := strings.IndexAny(input[i:], blank)
+		if end < 0 || len(parts) == n-1 {
+			return append(parts, input[i:]), append(offsets, i)
+		}
+		parts, offsets = append(parts, input[i:i+end]), append(offsets, i)
+		i += end
+	}
+	return parts, offsets
+}
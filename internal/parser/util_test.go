@@ -130,3 +130,47 @@ func TestIsRange(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitFields(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		number   int
+		expected []string
+	}{
+		"Single spaces": {
+			input:    "a b c",
+			number:   -1,
+			expected: []string{"a", "b", "c"},
+		},
+		"Runs of blanks": {
+			input:    " a \t b\t\tc ",
+			number:   -1,
+			expected: []string{"a", "b", "c"},
+		},
+		"Rest verbatim": {
+			input:    "a  b\tc  d  e",
+			number:   3,
+			expected: []string{"a", "b", "c  d  e"},
+		},
+		"Fewer parts": {
+			input:    "a b",
+			number:   3,
+			expected: []string{"a", "b"},
+		},
+		"Empty": {
+			input:    " \t ",
+			number:   -1,
+			expected: nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parts := splitFields(testCase.input, testCase.number)
+
+			if !reflect.DeepEqual(parts, testCase.expected) {
+				t.Errorf("expected: %q\nbut got: %q", testCase.expected, parts)
+			}
+		})
+	}
+}